@@ -0,0 +1,111 @@
+package datadir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const (
+	// EnvVar overrides the data directory, e.g. for isolated test profiles
+	EnvVar = "GAMELAUNCHER_HOME"
+
+	// PortableMarker is the file that, placed next to the executable, keeps
+	// all data in a "data" directory beside it (e.g. on a USB drive)
+	PortableMarker = "gamelauncher.portable"
+
+	// portableDataDir is the data directory used in portable mode, relative
+	// to the executable
+	portableDataDir = "data"
+
+	// legacyDirName is the directory in the user's home used by default
+	legacyDirName = ".gamelauncher"
+)
+
+// Source tells how the data directory was chosen
+type Source string
+
+const (
+	SourceFlag     Source = "--data-dir flag"
+	SourceEnv      Source = EnvVar
+	SourcePortable Source = "portable mode"
+	SourceXDG      Source = "XDG_DATA_HOME"
+	SourceHome     Source = "home directory"
+)
+
+// Resolve returns the data directory, creating it if needed. The first of
+// these wins:
+//
+//  1. flagDir, from the --data-dir command line flag
+//  2. the GAMELAUNCHER_HOME environment variable
+//  3. a "data" directory next to the executable if PortableMarker exists there
+//  4. on Linux, $XDG_DATA_HOME/gamelauncher (~/.local/share/gamelauncher),
+//     unless a ~/.gamelauncher directory from an older version exists
+//  5. ~/.gamelauncher
+func Resolve(flagDir string) (string, Source, error) {
+	dir, source := locate(flagDir)
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", source, fmt.Errorf("invalid data directory %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(abs, 0755); err != nil {
+		return "", source, fmt.Errorf("failed to create data directory %s: %w", abs, err)
+	}
+	return abs, source, nil
+}
+
+// locate picks the data directory without touching the file system
+func locate(flagDir string) (string, Source) {
+	if flagDir != "" {
+		return flagDir, SourceFlag
+	}
+	if env := os.Getenv(EnvVar); env != "" {
+		return env, SourceEnv
+	}
+	if exeDir, ok := portableDir(); ok {
+		return filepath.Join(exeDir, portableDataDir), SourcePortable
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	legacy := filepath.Join(home, legacyDirName)
+
+	if runtime.GOOS == "linux" {
+		if info, err := os.Stat(legacy); err != nil || !info.IsDir() {
+			dataHome := os.Getenv("XDG_DATA_HOME")
+			if dataHome == "" || !filepath.IsAbs(dataHome) {
+				dataHome = filepath.Join(home, ".local", "share")
+			}
+			return filepath.Join(dataHome, "gamelauncher"), SourceXDG
+		}
+	}
+
+	return legacy, SourceHome
+}
+
+// portableDir returns the executable's directory if it contains the portable
+// marker
+func portableDir() (string, bool) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", false
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	dir := filepath.Dir(exe)
+	if _, err := os.Stat(filepath.Join(dir, PortableMarker)); err != nil {
+		return "", false
+	}
+	return dir, true
+}
+
+// ImagesDir returns the directory holding downloaded game images
+func ImagesDir(dataDir string) string {
+	return filepath.Join(dataDir, "images")
+}
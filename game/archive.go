@@ -0,0 +1,265 @@
+package game
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// archiveExtensions are the archive types that can be installed, longest
+// first so ".tar.gz" wins over ".gz"
+var archiveExtensions = []string{".tar.gz", ".tar.bz2", ".tgz", ".tbz2", ".tar", ".zip", ".7z", ".rar"}
+
+// sevenZipBinaries are tried in order to extract .7z and .rar archives
+var sevenZipBinaries = []string{"7z", "7zz", "7za"}
+
+// IsArchive reports whether path is an archive type that can be installed
+func IsArchive(path string) bool {
+	return archiveExtension(path) != ""
+}
+
+// archiveExtension returns the archive extension of path, or "" if it is
+// not an archive
+func archiveExtension(path string) string {
+	lower := strings.ToLower(path)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// archiveBaseName returns the file name of an archive without its
+// extension, e.g. "Game-0.8-pc" for "Game-0.8-pc.zip"
+func archiveBaseName(path string) string {
+	name := filepath.Base(path)
+	return name[:len(name)-len(archiveExtension(name))]
+}
+
+// extractArchive unpacks the archive at path into dest, which must exist
+func extractArchive(path, dest string) error {
+	switch archiveExtension(path) {
+	case ".zip":
+		return extractZip(path, dest)
+	case ".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2":
+		return extractTar(path, dest)
+	case ".7z", ".rar":
+		return extractSevenZip(path, dest)
+	}
+	return fmt.Errorf("unsupported archive: %s", filepath.Base(path))
+}
+
+func extractZip(path, dest string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		target, err := archiveTarget(dest, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.Mode()&os.ModeSymlink != 0 {
+			// Links are skipped, as for tar archives
+			continue
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveFile(target, rc, f.Mode())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTar(path, dest string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	switch archiveExtension(path) {
+	case ".tar.gz", ".tgz":
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case ".tar.bz2", ".tbz2":
+		r = bzip2.NewReader(file)
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := archiveTarget(dest, header.Name)
+		if err != nil {
+			return err
+		}
+		// Only folders and regular files are extracted. Links are never
+		// created: a chain of links that each look harmless can point later
+		// entries outside the install folder.
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, header.FileInfo().Mode()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractSevenZip unpacks .7z and .rar archives with the 7-Zip command line
+// tool, which has no counterpart in the standard library. 7-Zip creates links
+// and trusts entry names, so the entries are listed and checked first, and
+// the extracted files are checked again for links 7-Zip created anyway.
+func extractSevenZip(path, dest string) error {
+	for _, name := range sevenZipBinaries {
+		binary, err := exec.LookPath(name)
+		if err != nil {
+			continue
+		}
+
+		listing, err := exec.Command(binary, "l", "-slt", "--", path).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s failed: %v: %s", name, err, strings.TrimSpace(string(listing)))
+		}
+		if err := checkSevenZipListing(dest, string(listing)); err != nil {
+			return err
+		}
+
+		out, err := exec.Command(binary, "x", "-y", "-o"+dest, "--", path).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s failed: %v: %s", name, err, strings.TrimSpace(string(out)))
+		}
+		return checkExtracted(dest)
+	}
+	return fmt.Errorf("extracting %s needs 7-Zip (7z) installed", filepath.Base(path))
+}
+
+// checkSevenZipListing refuses archives with links or entries outside dest,
+// given the technical listing of "7z l -slt"
+func checkSevenZipListing(dest, listing string) error {
+	// The entries follow a dashed line; the block above describes the archive
+	listing = strings.ReplaceAll(listing, "\r\n", "\n")
+	_, entries, found := strings.Cut(listing, "\n----------\n")
+	if !found {
+		return fmt.Errorf("unexpected 7-Zip listing")
+	}
+
+	for _, block := range strings.Split(entries, "\n\n") {
+		fields := make(map[string]string)
+		for _, line := range strings.Split(block, "\n") {
+			if key, value, ok := strings.Cut(line, " = "); ok {
+				fields[key] = value
+			}
+		}
+		name, ok := fields["Path"]
+		if !ok {
+			continue
+		}
+
+		if filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "\\") {
+			return fmt.Errorf("archive entry %q points outside the install folder", name)
+		}
+		for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+			if part == ".." {
+				return fmt.Errorf("archive entry %q points outside the install folder", name)
+			}
+		}
+		if _, err := archiveTarget(dest, name); err != nil {
+			return err
+		}
+
+		if fields["Symbolic Link"] != "" || fields["Hard Link"] != "" || fields["Link"] != "" {
+			return fmt.Errorf("archive entry %q is a link, which is not installed", name)
+		}
+		// Unix modes show up as e.g. "A_ lrwxrwxrwx"
+		for _, attr := range strings.Fields(fields["Attributes"]) {
+			if len(attr) == 10 && attr[0] == 'l' {
+				return fmt.Errorf("archive entry %q is a link, which is not installed", name)
+			}
+		}
+	}
+	return nil
+}
+
+// checkExtracted refuses the extracted files if any of them is a link
+func checkExtracted(dest string) error {
+	return filepath.WalkDir(dest, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type()&os.ModeSymlink != 0 {
+			rel, _ := filepath.Rel(dest, path)
+			return fmt.Errorf("archive entry %q is a link, which is not installed", filepath.ToSlash(rel))
+		}
+		return nil
+	})
+}
+
+// archiveTarget returns where an archive entry is extracted, refusing
+// entries that would end up outside dest
+func archiveTarget(dest, name string) (string, error) {
+	target := filepath.Join(dest, filepath.FromSlash(name))
+	if !withinDir(dest, target) {
+		return "", fmt.Errorf("archive entry %q points outside the install folder", name)
+	}
+	return target, nil
+}
+
+// withinDir reports whether path is dir or inside it
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// writeArchiveFile writes an extracted file, keeping its executable bits
+func writeArchiveFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,158 @@
+package game
+
+import (
+	"errors"
+	"gamelauncher/models"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// diskUsageMaxAge is how long a measured disk usage is trusted before the
+// game's folders are summed again
+const diskUsageMaxAge = 24 * time.Hour
+
+// Orders for SortByDiskUsage
+const (
+	UsageBySize = "size" // Largest first
+	UsageByName = "name"
+)
+
+// UsageOrders returns the orders SortByDiskUsage accepts
+func UsageOrders() []string {
+	return []string{UsageBySize, UsageByName}
+}
+
+// FolderSize returns the total size of the files below dir. Symbolic links
+// are not followed.
+func FolderSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Count what can be read rather than failing on one folder
+			if path != dir && errors.Is(err, fs.ErrPermission) {
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// DiskUsage returns the disk space taken by the game folder and the kept
+// installations. The result is cached on the game; it is measured again
+// when refresh is set, the cache is older than a day or the game was moved.
+func (m *Manager) DiskUsage(game *models.Game, refresh bool) (int64, error) {
+	if !refresh && !game.DiskUsageAt.IsZero() && time.Since(game.DiskUsageAt) < diskUsageMaxAge {
+		return game.DiskUsage, nil
+	}
+
+	var (
+		total int64
+		errs  []error
+	)
+	for _, folder := range m.installFolders(game) {
+		size, err := FolderSize(folder)
+		if err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+		total += size
+	}
+	if len(errs) > 0 {
+		return total, errors.Join(errs...)
+	}
+
+	game.DiskUsage = total
+	game.DiskUsageAt = time.Now()
+	return total, nil
+}
+
+// UpdateDiskUsage measures the games whose cached disk usage is missing or
+// out of date, or every game when refresh is set. It reports whether any
+// game was measured.
+func (m *Manager) UpdateDiskUsage(games []*models.Game, refresh bool) (bool, error) {
+	var (
+		changed bool
+		errs    []error
+	)
+	for _, game := range games {
+		measured := game.DiskUsageAt
+		if _, err := m.DiskUsage(game, refresh); err != nil {
+			errs = append(errs, err)
+		}
+		changed = changed || !game.DiskUsageAt.Equal(measured)
+	}
+	return changed, errors.Join(errs...)
+}
+
+// SortByDiskUsage returns the games ordered by cached disk usage, largest
+// first, or by name
+func SortByDiskUsage(games []*models.Game, order string) []*models.Game {
+	sorted := append([]*models.Game(nil), games...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if order != UsageByName && a.DiskUsage != b.DiskUsage {
+			return a.DiskUsage > b.DiskUsage
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	return sorted
+}
+
+// TotalDiskUsage sums the cached disk usage of the games
+func TotalDiskUsage(games []*models.Game) int64 {
+	var total int64
+	for _, game := range games {
+		total += game.DiskUsage
+	}
+	return total
+}
+
+// installFolders returns the game folder and the folders of the kept
+// installations, skipping folders inside another so nothing is counted or
+// removed twice
+func (m *Manager) installFolders(game *models.Game) []string {
+	candidates := []string{}
+	if game.Folder != "" || game.Executable != "" {
+		candidates = append(candidates, m.currentFolder(game))
+	}
+	for _, installed := range game.InstalledVersions {
+		if installed.Folder != "" {
+			candidates = append(candidates, m.cleanPath(installed.Folder))
+		}
+	}
+
+	var folders []string
+	for i, folder := range candidates {
+		nested := false
+		for j, other := range candidates {
+			if i != j && withinDir(other, folder) && (folder != other || j < i) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			folders = append(folders, folder)
+		}
+	}
+	return folders
+}
+
+// forgetDiskUsage drops the cached disk usage after the game's folders
+// changed
+func forgetDiskUsage(game *models.Game) {
+	game.DiskUsage = 0
+	game.DiskUsageAt = time.Time{}
+}
@@ -0,0 +1,255 @@
+package game
+
+import (
+	"gamelauncher/models"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Engines detected in game folders, named like the F95zone prefixes
+const (
+	EngineRenPy  = "Ren'Py"
+	EngineRPGM   = "RPGM"
+	EngineUnity  = "Unity"
+	EngineUnreal = "Unreal Engine"
+	EngineHTML   = "HTML"
+)
+
+// Engines returns the engines DetectEngine can report
+func Engines() []string {
+	return []string{EngineRenPy, EngineRPGM, EngineUnity, EngineUnreal, EngineHTML}
+}
+
+// DetectEngine inspects a game folder and returns its engine, or "" if it
+// is not recognised. HTML is only reported when no other engine matches,
+// since RPG Maker and Unity WebGL builds ship an index.html too.
+func DetectEngine(dir string) string {
+	switch {
+	case isRenPy(dir):
+		return EngineRenPy
+	case isRPGM(dir):
+		return EngineRPGM
+	case unityDataDir(dir) != "":
+		return EngineUnity
+	case isUnreal(dir):
+		return EngineUnreal
+	case fileExists(filepath.Join(dir, "index.html")):
+		return EngineHTML
+	}
+	return ""
+}
+
+// isRenPy looks for the renpy/ runtime next to a game/ folder with archives
+// or scripts
+func isRenPy(dir string) bool {
+	if !dirExists(filepath.Join(dir, "renpy")) {
+		return false
+	}
+	for _, pattern := range []string{"*.rpa", "*.rpyc", "*.rpy"} {
+		if matches, _ := filepath.Glob(filepath.Join(dir, "game", pattern)); len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isRPGM looks for the RPG Maker MV (www/js/rpg_core.js) or MZ
+// (js/rmmz_core.js) core scripts
+func isRPGM(dir string) bool {
+	return fileExists(filepath.Join(dir, "www", "js", "rpg_core.js")) ||
+		fileExists(filepath.Join(dir, "js", "rmmz_core.js"))
+}
+
+// unityDataDir returns the name of the <Game>_Data folder of a Unity build
+func unityDataDir(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !strings.HasSuffix(name, "_Data") {
+			continue
+		}
+		data := filepath.Join(dir, name)
+		if fileExists(filepath.Join(data, "globalgamemanagers")) || dirExists(filepath.Join(data, "Managed")) ||
+			dirExists(filepath.Join(data, "il2cpp_data")) {
+			return name
+		}
+	}
+	return ""
+}
+
+// isUnreal looks for the Engine/ folder of a packaged Unreal game
+func isUnreal(dir string) bool {
+	return dirExists(filepath.Join(dir, "Engine", "Binaries")) || dirExists(filepath.Join(dir, "Engine", "Content"))
+}
+
+// FindLauncher returns the file that starts a game of the given engine on
+// this platform, or "" if none was found. Native launchers are preferred;
+// on Linux a Windows executable is returned when there is nothing else, to
+// be run through Wine.
+func FindLauncher(dir, engine string) string {
+	launchers := findLaunchers(dir, engine)
+	if len(launchers) == 0 {
+		return ""
+	}
+	return launchers[0]
+}
+
+// findLaunchers returns the files that can start a game of the given engine,
+// best first
+func findLaunchers(dir, engine string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasSuffix(entry.Name(), ".app") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+
+	var candidates []string
+	switch engine {
+	case EngineRenPy:
+		// Ren'Py ships <Name>.exe, <Name>-32.exe, <Name>.sh and <Name>.py
+		candidates = platformLaunchers(files, []string{".app"}, []string{".sh"}, []string{".exe"})
+		candidates = excludeSuffix(candidates, "-32.exe")
+	case EngineRPGM:
+		// NW.js builds for Linux start with the nw binary or a Game script
+		candidates = append(matching(files, "nw", "Game", "Game.sh"), platformLaunchers(files, []string{".app"}, nil, []string{".exe"})...)
+		if runtime.GOOS == "windows" {
+			candidates = matching(files, "Game.exe")
+		}
+	case EngineUnity:
+		// The launcher is named like the <Name>_Data folder
+		if data := unityDataDir(dir); data != "" {
+			base := strings.TrimSuffix(data, "_Data")
+			candidates = platformLaunchers(matchingPrefix(files, base), []string{".app"}, []string{".x86_64", ".x86", ""}, []string{".exe"})
+		}
+	case EngineUnreal:
+		candidates = platformLaunchers(files, []string{".app"}, []string{".sh"}, []string{".exe"})
+	case EngineHTML:
+		candidates = matching(files, "index.html")
+	}
+
+	launchers := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		launchers = append(launchers, filepath.Join(dir, c))
+	}
+	return launchers
+}
+
+// platformLaunchers returns the files with the extensions used on this
+// platform, followed on Linux and macOS by Windows executables
+func platformLaunchers(files []string, darwinExts, linuxExts, windowsExts []string) []string {
+	switch runtime.GOOS {
+	case "windows":
+		return withExtensions(files, windowsExts)
+	case "darwin":
+		return append(withExtensions(files, darwinExts), withExtensions(files, windowsExts)...)
+	default:
+		return append(withExtensions(files, linuxExts), withExtensions(files, windowsExts)...)
+	}
+}
+
+// withExtensions returns the files having one of exts, in the order of exts.
+// An empty extension matches files without one.
+func withExtensions(files, exts []string) []string {
+	var result []string
+	for _, ext := range exts {
+		for _, f := range files {
+			if strings.EqualFold(filepath.Ext(f), ext) {
+				result = append(result, f)
+			}
+		}
+	}
+	return result
+}
+
+// matching returns the files named exactly like one of names, in that order
+func matching(files []string, names ...string) []string {
+	var result []string
+	for _, name := range names {
+		for _, f := range files {
+			if f == name {
+				result = append(result, f)
+			}
+		}
+	}
+	return result
+}
+
+func matchingPrefix(files []string, base string) []string {
+	var result []string
+	for _, f := range files {
+		if strings.TrimSuffix(f, filepath.Ext(f)) == base {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func excludeSuffix(files []string, suffix string) []string {
+	var result []string
+	for _, f := range files {
+		if !strings.HasSuffix(strings.ToLower(f), suffix) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// createGameFromEngineDir creates a game for a folder built with a known
+// engine, or returns nil if the engine or its launcher was not found
+func (m *Manager) createGameFromEngineDir(dir string) *models.Game {
+	engine := DetectEngine(dir)
+	if engine == "" {
+		return nil
+	}
+	launchers := findLaunchers(dir, engine)
+	if len(launchers) == 0 {
+		return nil
+	}
+
+	game := m.createGameFromPath(launchers[0])
+	game.Engine = engine
+	game.AltExecutables = launchers[1:]
+	game.CurrentVersion = DetectVersion(dir, engine)
+	return game
+}
+
+// IsHTMLGame reports whether path is a web page opened in the browser
+func IsHTMLGame(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".html" || ext == ".htm"
+}
+
+// browserCommand returns the command that opens a page in the default browser
+func browserCommand(page string) []string {
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"cmd", "/c", "start", "", page}
+	case "darwin":
+		return []string{"open", page}
+	default:
+		return []string{"xdg-open", page}
+	}
+}
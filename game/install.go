@@ -0,0 +1,370 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"gamelauncher/models"
+	"gamelauncher/saves"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InstallOptions controls how InstallUpdate installs a downloaded archive
+type InstallOptions struct {
+	MigrateSaves bool // Copy the save folders of the current version into the new one
+	Keep         int  // Older versions kept for rollback, older ones are deleted
+}
+
+// NewInstallOptions builds the install options from the application settings
+func NewInstallOptions(settings *models.Settings) InstallOptions {
+	return InstallOptions{
+		MigrateSaves: settings.MigrateSaves,
+		Keep:         settings.KeepVersions,
+	}
+}
+
+// InstallUpdate installs a new version of game from a zip, tar or 7z
+// archive. The archive is extracted into its own folder next to the current
+// version, the executable is found again, saves are optionally copied over
+// and the game is switched to the new folder. The previous version is kept
+// for rollback, up to opts.Keep versions.
+func (m *Manager) InstallUpdate(game *models.Game, archive string, opts InstallOptions) error {
+	if m.IsRunning(game.ID) {
+		return fmt.Errorf("%s is running, close it before installing an update", game.Name)
+	}
+	if !IsArchive(archive) {
+		return fmt.Errorf("unsupported archive: %s", filepath.Base(archive))
+	}
+
+	// Updates frequently clobber saves, including those outside the game
+	if snapshots := m.saveManager(); snapshots != nil {
+		if _, err := snapshots.AutoSnapshot(game, saves.ReasonUpdate); err != nil {
+			return err
+		}
+	}
+
+	current := m.currentFolder(game)
+	libraryRoot := filepath.Dir(current)
+
+	// Extract next to the game so the final move is a cheap rename
+	staging, err := os.MkdirTemp(libraryRoot, ".installing-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	if err := extractArchive(archive, staging); err != nil {
+		return fmt.Errorf("failed to extract %s: %w", filepath.Base(archive), err)
+	}
+
+	// Most archives hold a single folder named like "Game-0.8-pc"
+	content, topName := staging, ""
+	if entries, err := os.ReadDir(staging); err == nil && len(entries) == 1 && entries[0].IsDir() {
+		topName = entries[0].Name()
+		content = filepath.Join(staging, topName)
+	}
+
+	target := installTarget(libraryRoot, topName, archiveBaseName(archive), game.Name)
+	if err := os.Rename(content, target); err != nil {
+		return err
+	}
+
+	installed := m.findInstalledGame(game, current, target)
+	if installed == nil {
+		os.RemoveAll(target)
+		return fmt.Errorf("no executable found in %s", filepath.Base(archive))
+	}
+
+	if opts.MigrateSaves {
+		if err := migrateSaves(current, target, installed.Engine); err != nil {
+			os.RemoveAll(target)
+			return fmt.Errorf("failed to copy saves: %w", err)
+		}
+	}
+
+	// Prefer the version in the archive name, then the installed files, then
+	// the version the source reported
+	newVersion := folderVersion(archiveBaseName(archive))
+	if newVersion == "" {
+		newVersion = installed.CurrentVersion
+	}
+	if newVersion == "" {
+		newVersion = game.Version
+	}
+
+	game.InstalledVersions = append([]models.InstalledVersion{currentInstallation(game, current)}, game.InstalledVersions...)
+
+	if game.WorkingDir != "" {
+		game.WorkingDir = rebasePath(game.WorkingDir, current, target)
+	} else if dir := filepath.Dir(installed.Executable); dir != target {
+		game.WorkingDir = dir
+	}
+	game.Folder = target
+	game.Executable = installed.Executable
+	game.AltExecutables = installed.AltExecutables
+	game.Fingerprint, _ = Fingerprint(target, installed.Executable)
+	if installed.Engine != "" {
+		game.Engine = installed.Engine
+	}
+	game.CurrentVersion = newVersion
+	game.InstalledAt = time.Now()
+	game.IsInstalled = true
+	forgetDiskUsage(game)
+
+	return pruneVersions(game, opts.Keep)
+}
+
+// Rollback switches game back to the kept installation at index in
+// InstalledVersions. The current installation is kept in its place so the
+// rollback can be undone.
+func (m *Manager) Rollback(game *models.Game, index int) error {
+	if m.IsRunning(game.ID) {
+		return fmt.Errorf("%s is running, close it before switching versions", game.Name)
+	}
+	if index < 0 || index >= len(game.InstalledVersions) {
+		return fmt.Errorf("no installed version %d", index)
+	}
+
+	previous := game.InstalledVersions[index]
+	if _, err := os.Stat(previous.Executable); err != nil {
+		return fmt.Errorf("version %s is no longer installed: %w", previous.Version, err)
+	}
+
+	current := m.currentFolder(game)
+	game.InstalledVersions[index] = currentInstallation(game, current)
+
+	game.WorkingDir = rebasePath(game.WorkingDir, current, previous.Folder)
+	game.Folder = previous.Folder
+	game.Executable = previous.Executable
+	game.AltExecutables = nil
+	game.Fingerprint, _ = Fingerprint(previous.Folder, previous.Executable)
+	if engine := DetectEngine(previous.Folder); engine != "" {
+		game.Engine = engine
+	}
+	game.CurrentVersion = previous.Version
+	game.InstalledAt = previous.InstalledAt
+	game.IsInstalled = true
+	return nil
+}
+
+// FindDownloads returns the archives in dir that look like downloads of
+// game, newest first
+func FindDownloads(dir string, game *models.Game) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type download struct {
+		path    string
+		modTime time.Time
+	}
+	var downloads []download
+	for _, entry := range entries {
+		if entry.IsDir() || !IsArchive(entry.Name()) {
+			continue
+		}
+		if nameSimilarity(game.Name, archiveBaseName(entry.Name())) < 30 {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		downloads = append(downloads, download{filepath.Join(dir, entry.Name()), info.ModTime()})
+	}
+
+	sort.Slice(downloads, func(i, j int) bool {
+		return downloads[i].modTime.After(downloads[j].modTime)
+	})
+	paths := make([]string, len(downloads))
+	for i, d := range downloads {
+		paths[i] = d.path
+	}
+	return paths, nil
+}
+
+// currentFolder returns the folder of the game's current installation
+func (m *Manager) currentFolder(game *models.Game) string {
+	if game.Folder != "" {
+		return m.cleanPath(game.Folder)
+	}
+	return filepath.Dir(m.cleanPath(game.Executable))
+}
+
+// findInstalledGame finds the executable of a freshly extracted version:
+// the same file as in the current version if it still exists, otherwise the
+// best match found by scanning the folder
+func (m *Manager) findInstalledGame(game *models.Game, current, target string) *models.Game {
+	scanned, _ := m.ScanFolder(target)
+	var found *models.Game
+	if len(scanned) > 0 {
+		found = scanned[0]
+	}
+
+	if rel, err := filepath.Rel(current, m.cleanPath(game.Executable)); err == nil && !strings.HasPrefix(rel, "..") {
+		if same := filepath.Join(target, rel); fileExists(same) {
+			if found == nil {
+				found = &models.Game{}
+			}
+			found.AltExecutables = removeString(append(found.AltExecutables, found.Executable), same)
+			found.Executable = same
+		}
+	}
+	if found == nil || found.Executable == "" {
+		return nil
+	}
+	return found
+}
+
+// installTarget picks a folder name for the new version that is not taken,
+// trying the archive's folder, the archive name and the game name
+func installTarget(libraryRoot string, names ...string) string {
+	var base string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || name == "." || name == ".." {
+			continue
+		}
+		if base == "" {
+			base = name
+		}
+		if path := filepath.Join(libraryRoot, name); !pathExists(path) {
+			return path
+		}
+	}
+	for i := 2; ; i++ {
+		if path := filepath.Join(libraryRoot, fmt.Sprintf("%s-%d", base, i)); !pathExists(path) {
+			return path
+		}
+	}
+}
+
+// migrateSaves copies the save folders of the installation in from into to
+func migrateSaves(from, to, engine string) error {
+	for _, folder := range saves.GameFolders(engine) {
+		src := filepath.Join(from, folder)
+		if !dirExists(src) {
+			continue
+		}
+		if err := copyDir(src, filepath.Join(to, folder)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// currentInstallation describes the current installation of game, in
+// folder, for keeping it in InstalledVersions. Only InstallUpdate sets
+// InstalledAt, so a zero time marks the folder the game was added from.
+func currentInstallation(game *models.Game, folder string) models.InstalledVersion {
+	return models.InstalledVersion{
+		Version:     game.CurrentVersion,
+		Folder:      folder,
+		Executable:  game.Executable,
+		InstalledAt: game.InstalledAt,
+		Managed:     !game.InstalledAt.IsZero(),
+	}
+}
+
+// pruneVersions deletes the kept installations beyond the newest keep.
+// Folders the launcher did not create, such as the one the game was first
+// added from, are only forgotten, never deleted.
+func pruneVersions(game *models.Game, keep int) error {
+	if keep < 0 {
+		keep = 0
+	}
+	if len(game.InstalledVersions) <= keep {
+		return nil
+	}
+
+	var errs []error
+	kept := append([]models.InstalledVersion(nil), game.InstalledVersions[:keep]...)
+	for _, old := range game.InstalledVersions[keep:] {
+		if !old.Managed {
+			continue
+		}
+		if err := removeInstallation(game, old.Folder); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove version %s: %w", old.Version, err))
+			kept = append(kept, old)
+		}
+	}
+	game.InstalledVersions = kept
+	return errors.Join(errs...)
+}
+
+// removeInstallation deletes the folder of an old installation unless the
+// current version lives in or above it
+func removeInstallation(game *models.Game, folder string) error {
+	if folder == "" || withinDir(folder, game.Folder) {
+		return nil
+	}
+	return os.RemoveAll(folder)
+}
+
+// rebasePath moves path from below oldRoot to below newRoot. Paths outside
+// oldRoot are returned unchanged.
+func rebasePath(path, oldRoot, newRoot string) string {
+	if path == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(oldRoot, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.Join(newRoot, rel)
+}
+
+// copyDir copies the files below src into dst, replacing existing files
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
+
+func removeString(list []string, s string) []string {
+	var result []string
+	for _, item := range list {
+		if item != s && item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
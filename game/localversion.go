@@ -0,0 +1,140 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"gamelauncher/models"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// renpyVersionPattern matches `define config.version = "0.7.2"` and the
+	// older `config.version = "0.7.2"` inside an init block
+	renpyVersionPattern = regexp.MustCompile(`^\s*(?:define\s+)?config\.version\s*=\s*[uU]?["']([^"']+)["']`)
+
+	// folderVersionPatterns find versions in folder names such as
+	// "MyGame-0.7.2-pc", "MyGame v12" or "MyGame_Ep.3", in that order
+	folderVersionPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(?:^|[-_ .])v?(\d+(?:\.\d+)+[a-z]?)(?:[-_ ]|$)`),
+		regexp.MustCompile(`(?i)(?:^|[-_ ])v(\d+[a-z]?)(?:[-_ .]|$)`),
+		regexp.MustCompile(`(?i)(?:^|[-_ ])((?:ep|episode|ch|chapter|season)[-_ .]?\d+)(?:[-_ .]|$)`),
+	}
+
+	// textVersionPattern finds a dotted version anywhere in free text
+	textVersionPattern = regexp.MustCompile(`\bv?(\d+(?:\.\d+)+[a-z]?)\b`)
+)
+
+// DetectVersion infers the installed version of the game in dir from its
+// files, or returns "" if none was found. Version information written by the
+// engine is preferred over the folder name.
+func DetectVersion(dir, engine string) string {
+	var found string
+	switch engine {
+	case EngineRenPy:
+		found = renpyVersion(dir)
+	case EngineRPGM:
+		found = packageJSONVersion(dir)
+	case EngineUnity:
+		found = unityVersion(dir)
+	}
+	if found == "" {
+		found = folderVersion(filepath.Base(dir))
+	}
+	return found
+}
+
+// DetectInstalledVersion sets the game's current version from its local
+// files and reports whether it changed
+func (m *Manager) DetectInstalledVersion(game *models.Game) bool {
+	dir := game.Folder
+	if dir == "" {
+		dir = filepath.Dir(game.Executable)
+	}
+	engine := game.Engine
+	if engine == "" {
+		engine = DetectEngine(dir)
+	}
+
+	found := DetectVersion(dir, engine)
+	if found == "" || found == game.CurrentVersion {
+		return false
+	}
+	game.CurrentVersion = found
+	return true
+}
+
+// renpyVersion reads config.version from the game's scripts, options.rpy
+// first. script_version.txt is not used: it holds the version of Ren'Py the
+// game was built with, not the game's own.
+func renpyVersion(dir string) string {
+	scripts, _ := filepath.Glob(filepath.Join(dir, "game", "*.rpy"))
+	options := filepath.Join(dir, "game", "options.rpy")
+	for _, script := range append([]string{options}, scripts...) {
+		if found := scanLines(script, renpyVersionPattern); found != "" {
+			return found
+		}
+	}
+	return ""
+}
+
+// packageJSONVersion reads the version of an NW.js build of an RPG Maker
+// game, ignoring the template defaults
+func packageJSONVersion(dir string) string {
+	for _, path := range []string{filepath.Join(dir, "package.json"), filepath.Join(dir, "www", "package.json")} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var pkg struct {
+			Version string `json:"version"`
+		}
+		if json.Unmarshal(data, &pkg) != nil {
+			continue
+		}
+		if v := strings.TrimSpace(pkg.Version); v != "" && v != "0.0.0" && v != "1.0.0" {
+			return v
+		}
+	}
+	return ""
+}
+
+// unityVersion looks for a version in <Name>_Data/app.info, which holds the
+// company and product name; games often put their version in the latter
+func unityVersion(dir string) string {
+	data := unityDataDir(dir)
+	if data == "" {
+		return ""
+	}
+	return scanLines(filepath.Join(dir, data, "app.info"), textVersionPattern)
+}
+
+// folderVersion extracts a version from a folder name such as
+// "MyGame-0.7.2-pc"
+func folderVersion(name string) string {
+	for _, pattern := range folderVersionPatterns {
+		if m := pattern.FindStringSubmatch(name); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// scanLines returns the first submatch of pattern in the file at path
+func scanLines(path string, pattern *regexp.Regexp) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if m := pattern.FindStringSubmatch(scanner.Text()); m != nil {
+			return strings.TrimSpace(m[1])
+		}
+	}
+	return ""
+}
@@ -3,26 +3,43 @@ package game
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
+	"sync"
 	"gamelauncher/models"
+	"gamelauncher/saves"
 )
 
 // Manager handles game operations
-type Manager struct{}
+type Manager struct {
+	mu           sync.Mutex
+	launchMu     sync.Mutex          // Held from the running check until a launched game is tracked
+	running      map[string]*process // Launched games by ID, until they exit
+	onSessionEnd SessionHandler
+	runnerConfig RunnerConfig
+	scanOptions  ScanOptions
+	saves        *saves.Manager // Takes save snapshots before launches and updates, if set
+}
 
 // NewManager creates a new game manager
 func NewManager() *Manager {
-	return &Manager{}
+	return &Manager{
+		running:     make(map[string]*process),
+		scanOptions: NewScanOptions(models.DefaultSettings()),
+	}
 }
 
-// LaunchGame launches a game executable
+// LaunchGame launches a game executable and tracks it until it exits
 func (m *Manager) LaunchGame(game *models.Game) error {
 	if !game.IsInstalled {
 		return fmt.Errorf("game is not installed")
 	}
+	// Two quick launches must not both pass the check and start the game twice
+	m.launchMu.Lock()
+	defer m.launchMu.Unlock()
+	if m.IsRunning(game.ID) {
+		return fmt.Errorf("%s is already running", game.Name)
+	}
 	
 	// Clean the executable path (remove quotes and normalize)
 	executable := m.cleanPath(game.Executable)
@@ -32,57 +49,45 @@ func (m *Manager) LaunchGame(game *models.Game) error {
 		return fmt.Errorf("executable not found: %s", executable)
 	}
 	
-	// Launch the game
-	cmd := exec.Command(executable)
+	// Back up the saves first; a failed backup should not keep the game
+	// from starting
+	if snapshots := m.saveManager(); snapshots != nil {
+		if _, err := snapshots.AutoSnapshot(game, saves.ReasonLaunch); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+	
+	// Launch the game through its runner with its configured arguments and
+	// environment
+	cmd, err := m.command(game, executable)
+	if err != nil {
+		return err
+	}
 	
 	// Set working directory if available
-	if game.Folder != "" {
-		cmd.Dir = m.cleanPath(game.Folder)
+	if dir := game.LaunchDir(); dir != "" {
+		cmd.Dir = m.cleanPath(dir)
 	}
 	
-	return cmd.Start()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	m.track(game, cmd)
+	return nil
 }
 
-// ScanFolder scans a folder for potential games
-func (m *Manager) ScanFolder(folderPath string) ([]*models.Game, error) {
-	var games []*models.Game
-	
-	err := filepath.Walk(folderPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
-		
-		// Check if file is an executable
-		if m.isExecutable(path) {
-			game := m.createGameFromPath(path)
-			if game != nil {
-				games = append(games, game)
-			}
-		}
-		
-		return nil
-	})
-	
-	return games, err
+// SetSaveManager sets the snapshot manager used to back up saves before a
+// game is launched or updated
+func (m *Manager) SetSaveManager(snapshots *saves.Manager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saves = snapshots
 }
 
-// isExecutable checks if a file is an executable
-func (m *Manager) isExecutable(path string) bool {
-	ext := strings.ToLower(filepath.Ext(path))
-	
-	switch runtime.GOOS {
-	case "windows":
-		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
-	case "darwin":
-		return ext == ".app" || ext == "" // macOS apps can have no extension
-	default: // Linux
-		return ext == "" || ext == ".sh"
-	}
+func (m *Manager) saveManager() *saves.Manager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saves
 }
 
 // createGameFromPath creates a game from an executable path
@@ -0,0 +1,272 @@
+package game
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gamelauncher/models"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// hashChunk is how much of the start and end of a file is hashed. Together
+// with the size it tells files apart without reading multi-gigabyte files.
+const hashChunk = 64 << 10
+
+// fingerprintFiles are files that differ between games of the same engine,
+// relative to the game folder, in the order they are tried. Engine
+// launchers such as Ren'Py's .sh/.exe stubs, Unity players and NW.js are
+// byte-identical across games, so they can't tell games apart.
+var fingerprintFiles = []string{
+	filepath.Join("game", "*.rpa"),
+	filepath.Join("game", "*.rpyc"),
+	filepath.Join("*_Data", "globalgamemanagers"),
+	filepath.Join("*_Data", "mainData"),
+	filepath.Join("www", "data", "System.json"),
+	filepath.Join("data", "System.json"),
+	filepath.Join("*", "Content", "Paks", "*.pak"),
+}
+
+// minRelinkSimilarity is the name similarity, as scored by nameSimilarity,
+// a moved game must have to its old name or folder to be re-linked
+const minRelinkSimilarity = 10
+
+// LibraryChanges describes what Reconcile found in the library roots
+type LibraryChanges struct {
+	Pending  []*models.Game // Games in new folders, not yet in the library
+	Missing  []*models.Game // Games whose executable disappeared, now marked not installed
+	Restored []*models.Game // Games whose executable is back, now marked installed
+	Relinked []*models.Game // Games found again in a moved or renamed folder
+}
+
+// Changed reports whether any library game was modified
+func (c LibraryChanges) Changed() bool {
+	return len(c.Missing) > 0 || len(c.Restored) > 0 || len(c.Relinked) > 0
+}
+
+// Fingerprint identifies the game in folder, run by executable, after the
+// folder was moved or renamed. It hashes the game's data files, falling
+// back to the executable for games without known data files.
+func Fingerprint(folder, executable string) (string, error) {
+	for _, pattern := range fingerprintFiles {
+		matches, _ := filepath.Glob(filepath.Join(folder, pattern))
+		if len(matches) == 0 {
+			continue
+		}
+		sort.Strings(matches)
+		hash := sha256.New()
+		for _, match := range matches {
+			fileHash, err := hashFile(match)
+			if err != nil {
+				return "", err
+			}
+			rel, _ := filepath.Rel(folder, match)
+			fmt.Fprintf(hash, "%s\x00%s\n", filepath.ToSlash(rel), fileHash)
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+	return hashFile(executable)
+}
+
+// hashFile returns a hash of a file's size and the start and end of its
+// contents
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d\n", info.Size())
+	if _, err := io.CopyN(hash, file, hashChunk); err != nil && err != io.EOF {
+		return "", err
+	}
+	if info.Size() > 2*hashChunk {
+		if _, err := file.Seek(-hashChunk, io.SeekEnd); err != nil {
+			return "", err
+		}
+		if _, err := io.Copy(hash, file); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Reconcile compares the library with the folders below roots. Games whose
+// executable is gone are marked not installed, or re-linked when exactly one
+// new folder holds a game with the same fingerprint and a similar name;
+// games whose executable came back are marked installed again. Other new
+// game folders are returned as pending imports. The games are updated in
+// place. Roots that can't be read are skipped and their errors returned
+// together after the other roots were checked.
+func (m *Manager) Reconcile(games []*models.Game, roots []string) (LibraryChanges, error) {
+	var (
+		changes LibraryChanges
+		errs    []error
+	)
+
+	missing := make(map[string][]*models.Game) // By fingerprint
+	for _, game := range games {
+		exists := fileExists(m.cleanPath(game.Executable))
+		switch {
+		case exists && !game.IsInstalled:
+			game.IsInstalled = true
+			changes.Restored = append(changes.Restored, game)
+		case !exists && game.IsInstalled:
+			game.IsInstalled = false
+			changes.Missing = append(changes.Missing, game)
+		}
+
+		if exists && game.Fingerprint == "" {
+			game.Fingerprint, _ = Fingerprint(m.currentFolder(game), m.cleanPath(game.Executable))
+		}
+		if !exists && game.Fingerprint != "" {
+			missing[game.Fingerprint] = append(missing[game.Fingerprint], game)
+		}
+	}
+
+	for _, root := range roots {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, entry := range entries {
+			folder := filepath.Join(root, entry.Name())
+			if !entry.IsDir() || claimed(games, folder) {
+				continue
+			}
+
+			found, err := m.ScanFolder(folder)
+			if err != nil {
+				continue
+			}
+			for _, candidate := range found {
+				if game := m.relinkTarget(candidate, missing); game != nil {
+					missing[game.Fingerprint] = removeGame(missing[game.Fingerprint], game)
+					m.relink(game, candidate)
+					changes.Relinked = append(changes.Relinked, game)
+					changes.Missing = removeGame(changes.Missing, game)
+					continue
+				}
+				changes.Pending = append(changes.Pending, candidate)
+			}
+		}
+	}
+	return changes, errors.Join(errs...)
+}
+
+// relinkTarget returns the missing game the candidate is a moved copy of:
+// the only one with the same fingerprint and a similar name. Candidates
+// matching several games are left for the user to import.
+func (m *Manager) relinkTarget(candidate *models.Game, missing map[string][]*models.Game) *models.Game {
+	if len(missing) == 0 {
+		return nil
+	}
+	for _, path := range append([]string{candidate.Executable}, candidate.AltExecutables...) {
+		fingerprint, err := Fingerprint(candidate.Folder, path)
+		if err != nil {
+			continue
+		}
+		var matches []*models.Game
+		for _, game := range missing[fingerprint] {
+			if m.similarNames(game, candidate) {
+				matches = append(matches, game)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			// The executable that matched becomes the game's executable
+			if path != candidate.Executable {
+				candidate.AltExecutables = removeString(append(candidate.AltExecutables, candidate.Executable), path)
+				candidate.Executable = path
+			}
+			candidate.Fingerprint = fingerprint
+			return matches[0]
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// similarNames reports whether a candidate's name, folder or executable
+// resembles the missing game's, so games sharing files such as a common
+// engine build aren't mistaken for each other
+func (m *Manager) similarNames(game, candidate *models.Game) bool {
+	pairs := [][2]string{
+		{game.Name, candidate.Name},
+		{filepath.Base(m.currentFolder(game)), filepath.Base(candidate.Folder)},
+		{executableName(game.Executable), executableName(candidate.Executable)},
+	}
+	for _, pair := range pairs {
+		if nameSimilarity(pair[0], pair[1]) >= minRelinkSimilarity {
+			return true
+		}
+	}
+	return false
+}
+
+func executableName(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// relink points a game at the folder it was moved to, keeping its
+// configuration
+func (m *Manager) relink(game, found *models.Game) {
+	oldFolder := m.currentFolder(game)
+	game.WorkingDir = rebasePath(game.WorkingDir, oldFolder, found.Folder)
+	game.SaveDirs = rebasePaths(game.SaveDirs, oldFolder, found.Folder)
+	game.Folder = found.Folder
+	game.Executable = found.Executable
+	game.AltExecutables = found.AltExecutables
+	game.Fingerprint = found.Fingerprint
+	game.IsInstalled = true
+	forgetDiskUsage(game)
+}
+
+// claimed reports whether folder belongs to a game in the library: it is,
+// contains or lies within a game's folder
+func claimed(games []*models.Game, folder string) bool {
+	for _, game := range games {
+		if game.Folder == "" {
+			continue
+		}
+		gameFolder := filepath.Clean(game.Folder)
+		if withinDir(folder, gameFolder) || withinDir(gameFolder, folder) {
+			return true
+		}
+	}
+	return false
+}
+
+func rebasePaths(paths []string, oldRoot, newRoot string) []string {
+	var result []string
+	for _, p := range paths {
+		result = append(result, rebasePath(p, oldRoot, newRoot))
+	}
+	return result
+}
+
+func removeGame(games []*models.Game, game *models.Game) []*models.Game {
+	var result []*models.Game
+	for _, g := range games {
+		if g != game {
+			result = append(result, g)
+		}
+	}
+	return result
+}
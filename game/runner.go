@@ -0,0 +1,214 @@
+package game
+
+import (
+	"fmt"
+	"gamelauncher/models"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Runners a game can be started with. An empty runner picks one from the
+// executable, see ResolveRunner.
+const (
+	RunnerAuto   = ""
+	RunnerNative = "native"
+	RunnerWine   = "wine"
+	RunnerProton = "proton"
+)
+
+// RunnerConfig holds the launcher-wide runner settings
+type RunnerConfig struct {
+	WinePath   string // Wine binary, "wine" from PATH if empty
+	ProtonPath string // The proton script of a Proton installation
+	PrefixRoot string // Directory for Proton prefixes of games without their own
+}
+
+// Runner builds the command line and environment used to start a game
+type Runner interface {
+	// Command returns the program and arguments that run executable
+	Command(game *models.Game, executable string, cfg RunnerConfig) ([]string, error)
+
+	// Env returns environment variables the runner needs
+	Env(game *models.Game, cfg RunnerConfig) ([]string, error)
+}
+
+// prefixDir is the directory inside the data directory holding the Proton
+// prefixes created for games
+const prefixDir = "prefixes"
+
+// NewRunnerConfig builds the runner settings from the application settings
+func NewRunnerConfig(settings *models.Settings, dataDir string) RunnerConfig {
+	return RunnerConfig{
+		WinePath:   settings.WinePath,
+		ProtonPath: settings.ProtonPath,
+		PrefixRoot: filepath.Join(dataDir, prefixDir),
+	}
+}
+
+var runners = map[string]Runner{
+	RunnerNative: nativeRunner{},
+	RunnerWine:   wineRunner{},
+	RunnerProton: protonRunner{},
+}
+
+// Runners returns the runner names a game can be configured with
+func Runners() []string {
+	return []string{RunnerNative, RunnerWine, RunnerProton}
+}
+
+// IsWindowsExecutable reports whether path is a Windows program
+func IsWindowsExecutable(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".exe" || ext == ".bat" || ext == ".msi"
+}
+
+// ResolveRunner returns the runner used for game: its configured runner, or
+// Wine for Windows executables outside Windows and native otherwise
+func ResolveRunner(game *models.Game) string {
+	if game.Runner != RunnerAuto {
+		return game.Runner
+	}
+	if runtime.GOOS != "windows" && IsWindowsExecutable(game.Executable) {
+		return RunnerWine
+	}
+	return RunnerNative
+}
+
+// SetRunnerConfig sets the Wine and Proton settings used to launch games
+func (m *Manager) SetRunnerConfig(cfg RunnerConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runnerConfig = cfg
+}
+
+// command builds the command that starts game through its runner and wrapper
+func (m *Manager) command(game *models.Game, executable string) (*exec.Cmd, error) {
+	m.mu.Lock()
+	cfg := m.runnerConfig
+	m.mu.Unlock()
+
+	name := ResolveRunner(game)
+	runner, ok := runners[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown runner %q", name)
+	}
+
+	argv, err := runner.Command(game, executable, cfg)
+	if err != nil {
+		return nil, err
+	}
+	argv = append(append([]string(nil), game.Wrapper...), argv...)
+	argv = append(argv, game.LaunchArgs...)
+
+	env, err := runner.Env(game, cfg)
+	if err != nil {
+		return nil, err
+	}
+	// The game's own variables come last so they can override the runner's
+	env = append(env, game.LaunchEnv...)
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return cmd, nil
+}
+
+// nativeRunner starts the executable directly, or opens HTML games in the
+// browser
+type nativeRunner struct{}
+
+func (nativeRunner) Command(game *models.Game, executable string, cfg RunnerConfig) ([]string, error) {
+	if IsHTMLGame(executable) {
+		return browserCommand(executable), nil
+	}
+	return []string{executable}, nil
+}
+
+func (nativeRunner) Env(game *models.Game, cfg RunnerConfig) ([]string, error) {
+	return nil, nil
+}
+
+// wineRunner starts Windows executables with Wine, in the game's prefix if
+// it has one
+type wineRunner struct{}
+
+func (wineRunner) Command(game *models.Game, executable string, cfg RunnerConfig) ([]string, error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("the Wine runner is not available on Windows")
+	}
+	wine := cfg.WinePath
+	if wine == "" {
+		wine = "wine"
+	}
+	if _, err := exec.LookPath(wine); err != nil {
+		return nil, fmt.Errorf("wine not found (%s), install it or set its path in the settings", wine)
+	}
+	return []string{wine, executable}, nil
+}
+
+func (wineRunner) Env(game *models.Game, cfg RunnerConfig) ([]string, error) {
+	if game.WinePrefix == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(game.WinePrefix, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create Wine prefix: %w", err)
+	}
+	return []string{"WINEPREFIX=" + game.WinePrefix}, nil
+}
+
+// protonRunner starts Windows executables with a Proton installation, using
+// the game's prefix or one per game below the prefix root
+type protonRunner struct{}
+
+func (protonRunner) Command(game *models.Game, executable string, cfg RunnerConfig) ([]string, error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("the Proton runner is not available on Windows")
+	}
+	if cfg.ProtonPath == "" {
+		return nil, fmt.Errorf("no Proton path configured, set it in the settings")
+	}
+	if _, err := os.Stat(cfg.ProtonPath); err != nil {
+		return nil, fmt.Errorf("proton not found: %w", err)
+	}
+	return []string{cfg.ProtonPath, "run", executable}, nil
+}
+
+func (protonRunner) Env(game *models.Game, cfg RunnerConfig) ([]string, error) {
+	prefix := game.WinePrefix
+	if prefix == "" {
+		if cfg.PrefixRoot == "" {
+			return nil, fmt.Errorf("no prefix configured for %s", game.Name)
+		}
+		prefix = filepath.Join(cfg.PrefixRoot, game.ID)
+	}
+	if err := os.MkdirAll(prefix, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create Proton prefix: %w", err)
+	}
+
+	env := []string{"STEAM_COMPAT_DATA_PATH=" + prefix}
+	if steam := steamClientPath(); steam != "" {
+		env = append(env, "STEAM_COMPAT_CLIENT_INSTALL_PATH="+steam)
+	}
+	return env, nil
+}
+
+// steamClientPath returns the Steam installation Proton expects, if any
+func steamClientPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	for _, dir := range []string{
+		filepath.Join(home, ".steam", "steam"),
+		filepath.Join(home, ".local", "share", "Steam"),
+	} {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+	}
+	return ""
+}
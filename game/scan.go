@@ -0,0 +1,386 @@
+package game
+
+import (
+	"gamelauncher/models"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ScanOptions limits which files and folders ScanFolder looks at
+type ScanOptions struct {
+	Ignore   []string // Glob patterns matched against names and paths relative to the scanned folder
+	MaxDepth int      // Folder levels below the scanned folder searched, 0 for no limit
+}
+
+// NewScanOptions builds the scan options from the application settings
+func NewScanOptions(settings *models.Settings) ScanOptions {
+	return ScanOptions{
+		Ignore:   settings.ScanIgnore,
+		MaxDepth: settings.ScanMaxDepth,
+	}
+}
+
+// SetScanOptions sets the ignore patterns and depth limit used by ScanFolder
+func (m *Manager) SetScanOptions(opts ScanOptions) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scanOptions = opts
+}
+
+// helperExecutables are lower-case glob patterns of programs shipped next
+// to games that are never the game itself: crash reporters, installers and
+// redistributables
+var helperExecutables = []string{
+	"unitycrashhandler*",
+	"notification_helper",
+	"crashpad_handler",
+	"crashreportclient",
+	"uecrashreporter*",
+	"unins[0-9][0-9][0-9]",
+	"uninstall*",
+	"vc_redist*",
+	"vcredist*",
+	"dxsetup",
+	"dxwebsetup",
+	"oalinst",
+	"dotnetfx*",
+	"ue4prereqsetup*",
+	"python",
+	"pythonw",
+	"zsync*",
+}
+
+// documentNames are extensionless files commonly shipped with games that
+// are not programs, for filesystems that mark every file executable
+var documentNames = []string{"license", "readme", "changelog", "copying", "credits", "authors", "notice"}
+
+// candidate is an executable found while scanning
+type candidate struct {
+	path string
+	info os.FileInfo
+}
+
+// ScanFolder scans a folder for games and returns one game per game folder.
+// Folders built with a known engine are one game started with the engine's
+// launcher. Other executables are grouped by the shallowest folder holding
+// one, and the most likely main program of each group becomes the game's
+// executable with the others kept as alternates. When the folder holds
+// several games, executables lying directly in it are ignored.
+func (m *Manager) ScanFolder(folderPath string) ([]*models.Game, error) {
+	m.mu.Lock()
+	opts := m.scanOptions
+	m.mu.Unlock()
+
+	root := m.cleanPath(folderPath)
+	var games []*models.Game
+	byDir := make(map[string][]candidate)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Unreadable folders are skipped rather than ending the scan
+			if info != nil && info.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		if path != root && ignored(rel, opts.Ignore) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.IsDir() {
+			// A folder built with a known engine is one game, started with
+			// the engine's launcher for this platform
+			if game := m.createGameFromEngineDir(path); game != nil {
+				games = append(games, game)
+				return filepath.SkipDir
+			}
+			if opts.MaxDepth > 0 && path != root && depth(rel) > opts.MaxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if m.isExecutable(path) && isGameCandidate(path, info) {
+			dir := filepath.Dir(path)
+			byDir[dir] = append(byDir[dir], candidate{path: path, info: info})
+		}
+		return nil
+	})
+	if err != nil {
+		return games, err
+	}
+
+	// Executables directly in a scanned library, such as a stray install.sh,
+	// must not turn the whole library into one game
+	library := isLibrary(root, byDir, len(games))
+	if library {
+		delete(byDir, root)
+	}
+	groups := groupByRoot(byDir)
+	if library {
+		groups = libraryGroups(root, groups)
+	}
+	for _, group := range groups {
+		games = append(games, m.createGameFromGroup(group.root, group.candidates))
+	}
+	for _, game := range games {
+		game.Fingerprint, _ = Fingerprint(game.Folder, game.Executable)
+	}
+	return games, nil
+}
+
+// gameGroup holds the executables found below one game folder
+type gameGroup struct {
+	root       string
+	candidates []candidate
+}
+
+// isLibrary reports whether the scanned root holds several games rather
+// than being one game's folder: at least two of its subfolders hold
+// executables or engine games. A game folder with helpers in e.g. lib/ has
+// only one.
+func isLibrary(root string, byDir map[string][]candidate, engineGames int) bool {
+	children := make(map[string]bool)
+	for dir := range byDir {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." {
+			continue
+		}
+		children[strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]] = true
+	}
+	return len(children)+engineGames >= 2
+}
+
+// libraryGroups moves groups found deeper in a library folder, e.g.
+// Game/bin, up to that folder when it holds no other game
+func libraryGroups(root string, groups []gameGroup) []gameGroup {
+	topFolder := func(dir string) string {
+		rel, _ := filepath.Rel(root, dir)
+		return filepath.Join(root, strings.SplitN(filepath.ToSlash(rel), "/", 2)[0])
+	}
+	perFolder := make(map[string]int)
+	for _, group := range groups {
+		perFolder[topFolder(group.root)]++
+	}
+	for i := range groups {
+		if top := topFolder(groups[i].root); perFolder[top] == 1 {
+			groups[i].root = top
+		}
+	}
+	return groups
+}
+
+// groupByRoot assigns every folder holding executables to the shallowest
+// folder above it that holds executables too
+func groupByRoot(byDir map[string][]candidate) []gameGroup {
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	// Parents sort before their subfolders
+	sort.Slice(dirs, func(i, j int) bool {
+		di, dj := strings.Count(dirs[i], string(filepath.Separator)), strings.Count(dirs[j], string(filepath.Separator))
+		if di != dj {
+			return di < dj
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	var groups []gameGroup
+	index := make(map[string]int) // Group of each game folder
+	for _, dir := range dirs {
+		owner, ok := -1, false
+		for child, parent := dir, filepath.Dir(dir); parent != child; child, parent = parent, filepath.Dir(parent) {
+			if owner, ok = index[parent]; ok {
+				break
+			}
+		}
+		if !ok {
+			owner = len(groups)
+			index[dir] = owner
+			groups = append(groups, gameGroup{root: dir})
+		}
+		groups[owner].candidates = append(groups[owner].candidates, byDir[dir]...)
+	}
+	return groups
+}
+
+// createGameFromGroup creates a game for the executables found below root,
+// using the best scoring one as the executable
+func (m *Manager) createGameFromGroup(root string, candidates []candidate) *models.Game {
+	folderName := filepath.Base(root)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		si, sj := scoreExecutable(root, folderName, candidates[i]), scoreExecutable(root, folderName, candidates[j])
+		if si != sj {
+			return si > sj
+		}
+		return candidates[i].path < candidates[j].path
+	})
+
+	primary := candidates[0].path
+	game := models.NewGame(strings.TrimSpace(folderName), primary, root)
+	game.CurrentVersion = DetectVersion(root, "")
+	if dir := filepath.Dir(primary); dir != root {
+		game.WorkingDir = dir
+	}
+	for _, c := range candidates[1:] {
+		game.AltExecutables = append(game.AltExecutables, c.path)
+	}
+	return game
+}
+
+// scoreExecutable rates how likely an executable is the main program of the
+// game in root. Names close to the folder name, big files, native launchers
+// and files directly in the game folder rate higher.
+func scoreExecutable(root, folderName string, c candidate) int {
+	score := 0
+
+	base := strings.TrimSuffix(filepath.Base(c.path), filepath.Ext(c.path))
+	score += nameSimilarity(folderName, base)
+
+	// One point per MiB, the engine binary usually dwarfs its helpers
+	if mb := int(c.info.Size() >> 20); mb < 20 {
+		score += mb
+	} else {
+		score += 20
+	}
+
+	if runtime.GOOS != "windows" {
+		if c.info.Mode()&0o111 != 0 {
+			score += 10
+		}
+		if !IsWindowsExecutable(c.path) {
+			score += 15
+		}
+	}
+
+	if rel, err := filepath.Rel(root, filepath.Dir(c.path)); err == nil && rel != "." {
+		score -= 5 * (depth(rel) + 1)
+	}
+
+	lower := strings.ToLower(base)
+	if strings.HasSuffix(lower, "-32") || strings.HasSuffix(lower, "32bit") || strings.HasSuffix(lower, "_x86") {
+		score -= 5
+	}
+	return score
+}
+
+// nameSimilarity compares a folder name such as "MyGame-0.7.2-pc" with an
+// executable name such as "MyGame"
+func nameSimilarity(folderName, exeName string) int {
+	folder, exe := nameWords(folderName), nameWords(exeName)
+	if len(folder) == 0 || len(exe) == 0 {
+		return 0
+	}
+
+	joinedFolder, joinedExe := strings.Join(folder, ""), strings.Join(exe, "")
+	switch {
+	case joinedFolder == joinedExe:
+		return 50
+	case strings.HasPrefix(joinedFolder, joinedExe) || strings.HasPrefix(joinedExe, joinedFolder):
+		return 30
+	}
+
+	shared := 0
+	for _, w := range exe {
+		for _, f := range folder {
+			if w == f && !isNumber(w) {
+				shared++
+				break
+			}
+		}
+	}
+	if shared > 3 {
+		shared = 3
+	}
+	return 10 * shared
+}
+
+// nameWords splits a name into lower-case words of letters and digits
+func nameWords(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isGameCandidate filters out helper programs, and on Linux and macOS
+// extensionless files that are documents or lack the executable bit
+func isGameCandidate(path string, info os.FileInfo) bool {
+	name := strings.ToLower(filepath.Base(path))
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	for _, pattern := range helperExecutables {
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return false
+		}
+	}
+
+	if runtime.GOOS != "windows" && filepath.Ext(name) == "" {
+		for _, doc := range documentNames {
+			if base == doc {
+				return false
+			}
+		}
+		return info.Mode()&0o111 != 0
+	}
+	return true
+}
+
+// ignored reports whether the name or the relative path rel matches one of
+// the ignore patterns, ignoring case
+func ignored(rel string, patterns []string) bool {
+	rel = strings.ToLower(filepath.ToSlash(rel))
+	name := rel[strings.LastIndex(rel, "/")+1:]
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(filepath.ToSlash(strings.TrimSpace(pattern)))
+		if pattern == "" {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// depth returns how many folders deep a relative path is, 1 for "a"
+func depth(rel string) int {
+	if rel == "." || rel == "" {
+		return 0
+	}
+	return strings.Count(filepath.ToSlash(rel), "/") + 1
+}
+
+// isExecutable checks if a file is an executable
+func (m *Manager) isExecutable(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	switch runtime.GOOS {
+	case "windows":
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	case "darwin":
+		return ext == ".app" || ext == "" // macOS apps can have no extension
+	default: // Linux, Windows games run through Wine or Proton
+		return ext == "" || ext == ".sh" || ext == ".x86_64" || ext == ".x86" || IsWindowsExecutable(path)
+	}
+}
@@ -0,0 +1,223 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"gamelauncher/models"
+)
+
+// writeTree creates the files below root, making scripts, Unity players and
+// NW.js executable. The fixtures use Linux launchers, which ScanFolder only
+// picks up on Linux and other unix systems.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("scan fixtures use Linux launchers")
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		mode := os.FileMode(0o644)
+		if ext := filepath.Ext(name); ext == ".sh" || ext == ".x86_64" || filepath.Base(name) == "nw" {
+			mode = 0o755
+		}
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// gamesByFolder returns the scanned games by their folder relative to root
+func gamesByFolder(t *testing.T, root string, games []*models.Game) map[string]*models.Game {
+	t.Helper()
+	byFolder := make(map[string]*models.Game, len(games))
+	for _, game := range games {
+		rel, err := filepath.Rel(root, game.Folder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		byFolder[filepath.ToSlash(rel)] = game
+	}
+	return byFolder
+}
+
+func folderNames(byFolder map[string]*models.Game) []string {
+	names := make([]string, 0, len(byFolder))
+	for name := range byFolder {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestScanLibrary(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		// A stray script in the library itself is no game
+		"install.sh": "#!/bin/sh\n",
+
+		"RenGame/RenGame.sh":        "#!/bin/sh\n",
+		"RenGame/renpy/__init__.py": "",
+		"RenGame/game/archive.rpa":  "ren'py archive",
+
+		"RpgGame/nw":                   "nw.js",
+		"RpgGame/www/js/rpg_core.js":   "",
+		"RpgGame/www/data/System.json": `{"gameTitle": "RpgGame"}`,
+
+		"UnityGame/UnityGame.x86_64":                  "unity player",
+		"UnityGame/UnityGame_Data/globalgamemanagers": "unity data",
+
+		// Several versions side by side are separate games
+		"Story/Story-0.7/Story.sh": "#!/bin/sh\necho 0.7\n",
+		"Story/Story-0.8/Story.sh": "#!/bin/sh\necho 0.8\n",
+
+		// The launcher lives in a subfolder of the game folder
+		"Plain/bin/Plain.sh": "#!/bin/sh\n",
+	})
+
+	m := NewManager()
+	games, err := m.ScanFolder(root)
+	if err != nil {
+		t.Fatalf("ScanFolder: %v", err)
+	}
+	byFolder := gamesByFolder(t, root, games)
+
+	want := []string{"Plain", "RenGame", "RpgGame", "Story/Story-0.7", "Story/Story-0.8", "UnityGame"}
+	if got := folderNames(byFolder); len(got) != len(want) {
+		t.Fatalf("game folders = %v, want %v", got, want)
+	}
+	for _, folder := range want {
+		if byFolder[folder] == nil {
+			t.Errorf("no game found in %s, got %v", folder, folderNames(byFolder))
+		}
+	}
+
+	engines := map[string]string{
+		"RenGame":   EngineRenPy,
+		"RpgGame":   EngineRPGM,
+		"UnityGame": EngineUnity,
+		"Plain":     "",
+	}
+	for folder, engine := range engines {
+		if game := byFolder[folder]; game != nil && game.Engine != engine {
+			t.Errorf("%s engine = %q, want %q", folder, game.Engine, engine)
+		}
+	}
+
+	if game := byFolder["Plain"]; game != nil {
+		if game.Executable != filepath.Join(root, "Plain", "bin", "Plain.sh") {
+			t.Errorf("Plain executable = %s, want bin/Plain.sh", game.Executable)
+		}
+		if game.WorkingDir != filepath.Join(root, "Plain", "bin") {
+			t.Errorf("Plain working folder = %s, want bin", game.WorkingDir)
+		}
+	}
+
+	for folder, version := range map[string]string{"Story/Story-0.7": "0.7", "Story/Story-0.8": "0.8"} {
+		if game := byFolder[folder]; game != nil && game.CurrentVersion != version {
+			t.Errorf("%s version = %q, want %q", folder, game.CurrentVersion, version)
+		}
+	}
+
+	// Every game gets a fingerprint, and games differ by it
+	seen := make(map[string]string)
+	for folder, game := range byFolder {
+		if game.Fingerprint == "" {
+			t.Errorf("%s has no fingerprint", folder)
+			continue
+		}
+		if other, ok := seen[game.Fingerprint]; ok {
+			t.Errorf("%s and %s share a fingerprint", folder, other)
+		}
+		seen[game.Fingerprint] = folder
+	}
+}
+
+func TestScanSingleGameFolder(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "MyGame")
+	writeTree(t, root, map[string]string{
+		"MyGame.sh":         "#!/bin/sh\n",
+		"lib/helper.sh":     "#!/bin/sh\n",
+		"lib/x86_64/run.sh": "#!/bin/sh\n",
+	})
+
+	games, err := NewManager().ScanFolder(root)
+	if err != nil {
+		t.Fatalf("ScanFolder: %v", err)
+	}
+	if len(games) != 1 {
+		t.Fatalf("found %d games, want 1", len(games))
+	}
+	game := games[0]
+	if game.Folder != root || game.Executable != filepath.Join(root, "MyGame.sh") {
+		t.Errorf("game = %s in %s, want MyGame.sh in the scanned folder", game.Executable, game.Folder)
+	}
+	if len(game.AltExecutables) != 2 {
+		t.Errorf("alternate executables = %v, want the two helpers", game.AltExecutables)
+	}
+}
+
+func TestScanMaxDepth(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"Near/Near.sh":      "#!/bin/sh\n",
+		"Far/a/b/Far.sh":    "#!/bin/sh\n",
+		"Hidden/a/b/c/x.sh": "#!/bin/sh\n",
+	})
+
+	tests := []struct {
+		maxDepth int
+		want     int
+	}{
+		{0, 3}, // No limit
+		{1, 1},
+		{2, 1},
+		{3, 2},
+		{4, 3},
+	}
+
+	for _, tt := range tests {
+		m := NewManager()
+		m.SetScanOptions(ScanOptions{MaxDepth: tt.maxDepth})
+		games, err := m.ScanFolder(root)
+		if err != nil {
+			t.Fatalf("ScanFolder: %v", err)
+		}
+		if len(games) != tt.want {
+			t.Errorf("MaxDepth %d found %d games (%v), want %d",
+				tt.maxDepth, len(games), folderNames(gamesByFolder(t, root, games)), tt.want)
+		}
+	}
+}
+
+func TestFingerprintSurvivesRename(t *testing.T) {
+	parent := t.TempDir()
+	old := filepath.Join(parent, "RenGame-0.7")
+	writeTree(t, old, map[string]string{
+		"RenGame.sh":        "#!/bin/sh\n",
+		"renpy/__init__.py": "",
+		"game/archive.rpa":  "ren'py archive",
+	})
+	before, err := Fingerprint(old, filepath.Join(old, "RenGame.sh"))
+	if err != nil {
+		t.Fatalf("Fingerprint: %v", err)
+	}
+
+	moved := filepath.Join(parent, "RenGame")
+	if err := os.Rename(old, moved); err != nil {
+		t.Fatal(err)
+	}
+	after, err := Fingerprint(moved, filepath.Join(moved, "RenGame.sh"))
+	if err != nil {
+		t.Fatalf("Fingerprint: %v", err)
+	}
+	if before != after {
+		t.Error("fingerprint changed when the folder was renamed")
+	}
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"gamelauncher/models"
+	"os/exec"
+	"sort"
+	"time"
+)
+
+// RunningGame describes a game process started by the manager
+type RunningGame struct {
+	GameID  string
+	Name    string
+	PID     int
+	Started time.Time
+}
+
+// SessionHandler is called in the background when a launched game exits
+type SessionHandler func(game *models.Game, session models.Session)
+
+// process is a running game together with what is needed to wait for it
+type process struct {
+	info    RunningGame
+	done    chan struct{} // Closed once the session handler returned
+	session models.Session
+}
+
+// SetSessionHandler sets the function called when a launched game exits
+func (m *Manager) SetSessionHandler(handler SessionHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onSessionEnd = handler
+}
+
+// IsRunning reports whether a game launched by this manager is still running
+func (m *Manager) IsRunning(gameID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.running[gameID]
+	return ok
+}
+
+// Running returns the games launched by this manager that are still running,
+// oldest first
+func (m *Manager) Running() []RunningGame {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	running := make([]RunningGame, 0, len(m.running))
+	for _, proc := range m.running {
+		running = append(running, proc.info)
+	}
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].Started.Before(running[j].Started)
+	})
+	return running
+}
+
+// Wait blocks until the game exits and returns its session. It returns false
+// if the game is not running.
+func (m *Manager) Wait(gameID string) (models.Session, bool) {
+	m.mu.Lock()
+	proc, ok := m.running[gameID]
+	m.mu.Unlock()
+	if !ok {
+		return models.Session{}, false
+	}
+
+	<-proc.done
+	return proc.session, true
+}
+
+// track remembers a started game and waits for it in the background
+func (m *Manager) track(game *models.Game, cmd *exec.Cmd) {
+	proc := &process{
+		info: RunningGame{
+			GameID:  game.ID,
+			Name:    game.Name,
+			PID:     cmd.Process.Pid,
+			Started: time.Now(),
+		},
+		done: make(chan struct{}),
+	}
+
+	m.mu.Lock()
+	m.running[game.ID] = proc
+	m.mu.Unlock()
+
+	go func() {
+		err := cmd.Wait()
+		proc.session = models.Session{
+			Start:    proc.info.Started,
+			End:      time.Now(),
+			ExitCode: exitCode(cmd, err),
+		}
+
+		m.mu.Lock()
+		delete(m.running, game.ID)
+		handler := m.onSessionEnd
+		m.mu.Unlock()
+
+		if handler != nil {
+			handler(game, proc.session)
+		}
+		close(proc.done)
+	}()
+}
+
+// exitCode returns the exit code of a finished command, or -1 if it is unknown
+func exitCode(cmd *exec.Cmd, err error) int {
+	if cmd.ProcessState != nil {
+		return cmd.ProcessState.ExitCode()
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
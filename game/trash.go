@@ -0,0 +1,122 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrTrashUnavailable is returned by MoveToTrash when the file can't be
+// moved to the trash, e.g. because it lies on another drive than the trash
+// folder. Callers may offer to delete the file instead.
+var ErrTrashUnavailable = errors.New("trash is not available")
+
+// MoveToTrash moves a file or folder to the system trash: the Recycle Bin
+// on Windows, the Finder's trash on macOS and the freedesktop.org trash of
+// the user elsewhere
+func MoveToTrash(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(path); err != nil {
+		return err
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		return trashWindows(path)
+	case "darwin":
+		return trashDarwin(path)
+	default:
+		return trashFreedesktop(path)
+	}
+}
+
+// trashWindows sends path to the Recycle Bin through the .NET file system
+// helpers, which need no extra tools
+func trashWindows(path string) error {
+	method := "DeleteFile"
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		method = "DeleteDirectory"
+	}
+	script := fmt.Sprintf("Add-Type -AssemblyName Microsoft.VisualBasic; "+
+		"[Microsoft.VisualBasic.FileIO.FileSystem]::%s('%s', 'OnlyErrorDialogs', 'SendToRecycleBin')",
+		method, strings.ReplaceAll(path, "'", "''"))
+	output, err := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrTrashUnavailable, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// trashDarwin asks the Finder to move path to the trash, so it can be put
+// back from there
+func trashDarwin(path string) error {
+	quoted := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(path)
+	script := fmt.Sprintf(`tell application "Finder" to delete POSIX file "%s"`, quoted)
+	output, err := exec.Command("osascript", "-e", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrTrashUnavailable, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// trashFreedesktop moves path to the user's trash as described by the
+// freedesktop.org trash specification, writing the .trashinfo entry that file
+// managers use to restore it
+func trashFreedesktop(path string) error {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrTrashUnavailable, err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	trash := filepath.Join(dataHome, "Trash")
+	for _, dir := range []string{"files", "info"} {
+		if err := os.MkdirAll(filepath.Join(trash, dir), 0o700); err != nil {
+			return fmt.Errorf("%w: %v", ErrTrashUnavailable, err)
+		}
+	}
+
+	// The info file is created exclusively first to claim the name
+	base := filepath.Base(path)
+	for i := 1; ; i++ {
+		name := base
+		if i > 1 {
+			name = fmt.Sprintf("%s.%d", base, i)
+		}
+		infoPath := filepath.Join(trash, "info", name+".trashinfo")
+		info, err := os.OpenFile(infoPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrTrashUnavailable, err)
+		}
+
+		escaped := (&url.URL{Path: path}).EscapedPath()
+		_, err = fmt.Fprintf(info, "[Trash Info]\nPath=%s\nDeletionDate=%s\n", escaped, time.Now().Format("2006-01-02T15:04:05"))
+		if closeErr := info.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(path, filepath.Join(trash, "files", name))
+		}
+		if err != nil {
+			os.Remove(infoPath)
+			// Renaming fails across file systems; copying whole games into
+			// the home folder's trash is not worth it
+			return fmt.Errorf("%w: %v", ErrTrashUnavailable, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"gamelauncher/models"
+	"gamelauncher/saves"
+	"os"
+	"path/filepath"
+)
+
+// UninstallOptions controls what Uninstall removes and how
+type UninstallOptions struct {
+	Trash     bool // Move folders to the trash instead of deleting them
+	KeepSaves bool // Back up the saves first and leave save folders outside the game alone
+
+	// Save folders outside the game folder to remove as well, as listed by
+	// ExternalSaveFolders. Ignored when KeepSaves is set.
+	SaveFolders []string
+}
+
+// UninstallFolders returns the folders Uninstall removes: the game folder
+// and the kept installations
+func (m *Manager) UninstallFolders(game *models.Game) []string {
+	return m.installFolders(game)
+}
+
+// ExternalSaveFolders returns the save folders of game outside the game
+// folder, such as Ren'Py's and Unity's folders in the user profile. Most
+// are guessed from the engine, so they are only removed when the caller
+// listed them to the user and passes them in UninstallOptions.SaveFolders.
+func (m *Manager) ExternalSaveFolders(game *models.Game) []string {
+	installs := m.installFolders(game)
+	var external []string
+	for _, location := range saves.Locations(game) {
+		inside := false
+		for _, folder := range installs {
+			if withinDir(folder, location) {
+				inside = true
+				break
+			}
+		}
+		if !inside {
+			external = append(external, location)
+		}
+	}
+	return external
+}
+
+// Uninstall removes the files of game from disk and marks it not installed.
+// With KeepSaves the saves are snapshotted first under the game's ID, so
+// callers keep the game in the library to restore them after reinstalling;
+// otherwise the game's save snapshots are deleted too.
+// If the trash can't be used for a folder, ErrTrashUnavailable is returned
+// and the remaining folders are left in place.
+func (m *Manager) Uninstall(game *models.Game, opts UninstallOptions) error {
+	if m.IsRunning(game.ID) {
+		return fmt.Errorf("%s is running, close it before uninstalling", game.Name)
+	}
+
+	folders := m.UninstallFolders(game)
+	if !opts.KeepSaves {
+		folders = append(folders, opts.SaveFolders...)
+	}
+	for _, folder := range folders {
+		if err := checkRemovable(folder); err != nil {
+			return err
+		}
+	}
+
+	snapshots := m.saveManager()
+	if opts.KeepSaves {
+		if snapshots == nil {
+			return fmt.Errorf("saves of %s can't be kept without a backup folder", game.Name)
+		}
+		if _, err := snapshots.Snapshot(game, saves.ReasonUninstall); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, folder := range folders {
+		if !pathExists(folder) {
+			continue
+		}
+		var err error
+		if opts.Trash {
+			err = MoveToTrash(folder)
+		} else {
+			err = os.RemoveAll(folder)
+		}
+		if errors.Is(err, ErrTrashUnavailable) {
+			return fmt.Errorf("failed to move %s to the trash: %w", folder, err)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", folder, err))
+		}
+	}
+
+	if !opts.KeepSaves && snapshots != nil {
+		if err := snapshots.DeleteAll(game.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete save backups: %w", err))
+		}
+	}
+
+	game.IsInstalled = false
+	game.InstalledVersions = nil
+	forgetDiskUsage(game)
+	return errors.Join(errs...)
+}
+
+// checkRemovable refuses to remove folders whose loss would go far beyond
+// one game, such as a drive or the home folder, in case a game was set up
+// with one of them as its folder
+func checkRemovable(folder string) error {
+	clean := filepath.Clean(folder)
+	if !filepath.IsAbs(clean) || filepath.Dir(clean) == clean {
+		return fmt.Errorf("refusing to remove %s", folder)
+	}
+	if home, err := os.UserHomeDir(); err == nil && withinDir(clean, home) {
+		return fmt.Errorf("refusing to remove %s, it contains the home folder", folder)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle is how long the library roots must stay quiet before the
+// watcher reports a change, so extracting a game is reported once
+const watchSettle = 3 * time.Second
+
+// Watcher watches library roots and the game folders below them and calls
+// a function once changes have settled. It does not interpret the changes;
+// callers run Reconcile to find out what happened.
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	onChange func()
+
+	mu      sync.Mutex
+	roots   map[string]bool
+	watched map[string]bool
+	timer   *time.Timer
+	done    chan struct{}
+}
+
+// NewWatcher starts watching the library roots and their direct subfolders.
+// onChange is called in the background after files were added, removed or
+// renamed.
+func NewWatcher(roots []string, onChange func()) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		fs:       fsw,
+		onChange: onChange,
+		roots:    make(map[string]bool),
+		watched:  make(map[string]bool),
+		done:     make(chan struct{}),
+	}
+	for _, root := range roots {
+		if err := w.add(root); err != nil {
+			fsw.Close()
+			return nil, fmt.Errorf("failed to watch %s: %w", root, err)
+		}
+		w.roots[filepath.Clean(root)] = true
+		w.addChildren(root)
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+	close(w.done)
+	return w.fs.Close()
+}
+
+// WatchFolders adds folders to watch, such as the folders of library games
+// that lie outside the roots. Folders that don't exist are skipped.
+func (w *Watcher) WatchFolders(folders []string) {
+	for _, folder := range folders {
+		if folder != "" {
+			w.add(folder)
+		}
+	}
+}
+
+func (w *Watcher) run() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Create|fsnotify.Remove|fsnotify.Rename|fsnotify.Write) {
+				continue
+			}
+			// New folders in a root are games being added; watch them too
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() && w.isRootChild(event.Name) {
+					w.add(event.Name)
+				}
+			}
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				w.forget(event.Name)
+			}
+			w.schedule()
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			fmt.Printf("Warning: Library watcher: %v\n", err)
+		}
+	}
+}
+
+// schedule calls onChange once no event arrived for watchSettle
+func (w *Watcher) schedule() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(watchSettle, w.onChange)
+}
+
+func (w *Watcher) add(path string) error {
+	path = filepath.Clean(path)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watched[path] {
+		return nil
+	}
+	if err := w.fs.Add(path); err != nil {
+		return err
+	}
+	w.watched[path] = true
+	return nil
+}
+
+func (w *Watcher) addChildren(root string) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			w.add(filepath.Join(root, entry.Name()))
+		}
+	}
+}
+
+// forget drops a removed or renamed folder; fsnotify stops watching it on
+// its own
+func (w *Watcher) forget(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.watched, filepath.Clean(path))
+}
+
+// isRootChild reports whether path is directly inside a library root
+func (w *Watcher) isRootChild(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.roots[filepath.Dir(filepath.Clean(path))]
+}
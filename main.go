@@ -4,36 +4,88 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"gamelauncher/datadir"
 	"gamelauncher/game"
+	"gamelauncher/models"
+	"gamelauncher/monitor"
 	_ "gamelauncher/plugins/f95zone"
+	_ "gamelauncher/plugins/github"
+	"gamelauncher/saves"
 	"gamelauncher/search"
 	"gamelauncher/steam"
 	"gamelauncher/storage"
 	"gamelauncher/ui"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// dataDir is the directory holding the library, settings, images and caches
+var dataDir string
+
+// dataDirSource tells how dataDir was chosen, shown with the help
+var dataDirSource datadir.Source
+
 func main() {
+	args, flagDir, err := extractDataDirFlag(os.Args[1:])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		showUsage()
+		return
+	}
+
+	dir, source, err := datadir.Resolve(flagDir)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	dataDir, dataDirSource = dir, source
+
 	// Check for command-line arguments
-	if len(os.Args) > 1 {
-		handleCommandLineArgs()
+	if len(args) > 0 {
+		handleCommandLineArgs(args)
 		return
 	}
 
 	// Normal GUI mode
 	log.Println("Starting Game Launcher...")
-	app := ui.NewMainWindow()
+	app := ui.NewMainWindow(dataDir)
 	app.ShowAndRun()
 }
 
-// handleCommandLineArgs processes command-line arguments
-func handleCommandLineArgs() {
-	args := os.Args[1:]
+// extractDataDirFlag removes --data-dir <dir> or --data-dir=<dir> from args
+// and returns the remaining arguments and the directory
+func extractDataDirFlag(args []string) ([]string, string, error) {
+	var rest []string
+	dir := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-data-dir" || arg == "--data-dir":
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("%s requires a directory", arg)
+			}
+			i++
+			dir = args[i]
+		case strings.HasPrefix(arg, "-data-dir=") || strings.HasPrefix(arg, "--data-dir="):
+			dir = arg[strings.Index(arg, "=")+1:]
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, dir, nil
+}
 
+// handleCommandLineArgs processes command-line arguments
+func handleCommandLineArgs(args []string) {
 	if len(args) == 0 {
 		showUsage()
 		return
@@ -48,7 +100,18 @@ func handleCommandLineArgs() {
 		}
 		launchGameByNumber(args[1])
 	case "-list", "--list":
-		listGames()
+		var query storage.GameQuery
+		if len(args) > 1 {
+			query.Name = args[1]
+		}
+		listGames(query)
+	case "-tag", "--tag":
+		if len(args) < 2 {
+			fmt.Println("Error: Tag required")
+			showUsage()
+			return
+		}
+		listGames(storage.GameQuery{Tag: args[1]})
 	case "-search", "--search":
 		if len(args) < 2 {
 			fmt.Println("Error: Game name required")
@@ -63,8 +126,44 @@ func handleCommandLineArgs() {
 			return
 		}
 		addGameToSteamByNumber(args[1])
+	case "-install", "--install":
+		if len(args) < 3 {
+			fmt.Println("Error: Game number and archive required")
+			showUsage()
+			return
+		}
+		installUpdate(args[1], args[2], args[3:])
+	case "-uninstall", "--uninstall":
+		if len(args) < 2 {
+			fmt.Println("Error: Game number required")
+			showUsage()
+			return
+		}
+		uninstallGame(args[1], args[2:])
+	case "-usage", "--usage":
+		showDiskUsage(args[1:])
+	case "-check", "--check":
+		checkForUpdates()
+	case "-stats", "--stats":
+		showPlaytimeStats()
+	case "-export", "--export":
+		if len(args) < 2 {
+			fmt.Println("Error: Export file required")
+			showUsage()
+			return
+		}
+		exportLibrary(args[1])
+	case "-import", "--import":
+		if len(args) < 2 {
+			fmt.Println("Error: Import file required")
+			showUsage()
+			return
+		}
+		importLibrary(args[1], args[2:])
 	case "-help", "--help", "-h", "--h":
 		showUsage()
+		// On stderr, so scripts reading the help aren't affected
+		fmt.Fprintf(os.Stderr, "\nData directory: %s (%s)\n", dataDir, dataDirSource)
 	default:
 		fmt.Printf("Unknown option: %s\n", args[0])
 		showUsage()
@@ -74,8 +173,13 @@ func handleCommandLineArgs() {
 // launchGameByNumber launches a game by its number in the list
 func launchGameByNumber(gameNumber string) {
 	// Load games from storage
-	storage := storage.NewManager()
-	games, err := storage.LoadGames()
+	manager := storage.NewManager(dataDir)
+	store, err := openStore(manager)
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
 	if err != nil {
 		fmt.Printf("Error loading games: %v\n", err)
 		return
@@ -97,7 +201,7 @@ func launchGameByNumber(gameNumber string) {
 	index := num - 1
 	if index < 0 || index >= len(games) {
 		fmt.Printf("Game number %d not found. Available games:\n", num)
-		listGames()
+		listGames(storage.GameQuery{})
 		return
 	}
 
@@ -106,23 +210,363 @@ func launchGameByNumber(gameNumber string) {
 	fmt.Printf("Launching %s...\n", gameItem.Name)
 
 	gameManager := game.NewManager()
+	snapshots := saves.NewManager(dataDir)
+	if settings, err := manager.LoadSettings(); err == nil {
+		gameManager.SetRunnerConfig(game.NewRunnerConfig(settings, dataDir))
+		snapshots.SetKeep(settings.SaveSnapshots)
+	}
+	gameManager.SetSaveManager(snapshots)
 	err = gameManager.LaunchGame(gameItem)
 	if err != nil {
 		fmt.Printf("Error launching game: %v\n", err)
-	} else {
-		fmt.Printf("Successfully launched %s\n", gameItem.Name)
+		return
+	}
+	fmt.Printf("Successfully launched %s, waiting for it to exit...\n", gameItem.Name)
+
+	session, _ := gameManager.Wait(gameItem.ID)
+	fmt.Printf("%s exited (code %d) after %s\n", gameItem.Name, session.ExitCode, models.FormatPlaytime(session.Duration()))
+	recordSession(manager, store, gameItem.ID, session)
+}
+
+// installUpdate installs a downloaded archive as the new version of a game
+func installUpdate(gameNumber, archive string, options []string) {
+	manager := storage.NewManager(dataDir)
+	store, err := openStore(manager)
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
+	if err != nil {
+		fmt.Printf("Error loading games: %v\n", err)
+		return
+	}
+
+	num, err := strconv.Atoi(gameNumber)
+	if err != nil || num < 1 || num > len(games) {
+		fmt.Printf("Game number %s not found. Available games:\n", gameNumber)
+		listGames(storage.GameQuery{})
+		return
+	}
+	gameItem := games[num-1]
+
+	settings, err := manager.LoadSettings()
+	if err != nil {
+		settings = models.DefaultSettings()
+	}
+	opts := game.NewInstallOptions(settings)
+	for _, option := range options {
+		switch option {
+		case "-no-saves", "--no-saves":
+			opts.MigrateSaves = false
+		default:
+			fmt.Printf("Unknown install option: %s\n", option)
+			return
+		}
+	}
+
+	fmt.Printf("Installing %s for %s...\n", filepath.Base(archive), gameItem.Name)
+	gameManager := game.NewManager()
+	gameManager.SetScanOptions(game.NewScanOptions(settings))
+	snapshots := saves.NewManager(dataDir)
+	snapshots.SetKeep(settings.SaveSnapshots)
+	gameManager.SetSaveManager(snapshots)
+	previousFolder := gameItem.Folder
+	installErr := gameManager.InstallUpdate(gameItem, archive, opts)
+	if gameItem.Folder != previousFolder {
+		if err := store.SaveGame(gameItem); err != nil {
+			fmt.Printf("Error saving game: %v\n", err)
+			return
+		}
+	}
+	if installErr != nil {
+		fmt.Printf("Error installing update: %v\n", installErr)
+		return
+	}
+	fmt.Printf("Installed %s %s to %s\n", gameItem.Name, gameItem.CurrentVersion, gameItem.Folder)
+}
+
+// uninstallGame removes a game's files after asking, unless --yes is given.
+// With --no-saves the game is removed from the library too; otherwise it
+// stays as not installed so its save backups remain reachable.
+func uninstallGame(gameNumber string, options []string) {
+	manager := storage.NewManager(dataDir)
+	store, err := openStore(manager)
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
+	if err != nil {
+		fmt.Printf("Error loading games: %v\n", err)
+		return
+	}
+
+	num, err := strconv.Atoi(gameNumber)
+	if err != nil || num < 1 || num > len(games) {
+		fmt.Printf("Game number %s not found. Available games:\n", gameNumber)
+		listGames(storage.GameQuery{})
+		return
+	}
+	gameItem := games[num-1]
+
+	opts := game.UninstallOptions{Trash: true, KeepSaves: true}
+	confirmed := false
+	for _, option := range options {
+		switch option {
+		case "-delete", "--delete":
+			opts.Trash = false
+		case "-no-saves", "--no-saves":
+			opts.KeepSaves = false
+		case "-yes", "--yes":
+			confirmed = true
+		default:
+			fmt.Printf("Unknown uninstall option: %s\n", option)
+			return
+		}
+	}
+
+	gameManager := game.NewManager()
+	snapshots := saves.NewManager(dataDir)
+	gameManager.SetSaveManager(snapshots)
+
+	action := "Moving to the trash"
+	if !opts.Trash {
+		action = "Permanently deleting"
+	}
+	fmt.Printf("Uninstalling %s\n", gameItem.Name)
+	fmt.Printf("%s:\n", action)
+	for _, folder := range gameManager.UninstallFolders(gameItem) {
+		fmt.Printf("  %s\n", folder)
+	}
+	if !opts.KeepSaves {
+		// Listed apart: these lie outside the game folder and are mostly
+		// guessed from the engine
+		opts.SaveFolders = gameManager.ExternalSaveFolders(gameItem)
+		if len(opts.SaveFolders) > 0 {
+			fmt.Printf("%s these save folders outside the game folder:\n", action)
+			for _, folder := range opts.SaveFolders {
+				fmt.Printf("  %s\n", folder)
+			}
+		}
+	}
+	if opts.KeepSaves {
+		fmt.Println("Saves are backed up first; the game stays in the library as not installed")
+		fmt.Println("so they can be restored after reinstalling it.")
+	}
+
+	if !confirmed {
+		fmt.Print("Continue? [y/N] ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return
+		}
+	}
+
+	if err := gameManager.Uninstall(gameItem, opts); err != nil {
+		if errors.Is(err, game.ErrTrashUnavailable) {
+			fmt.Printf("Error: %v\nRun again with --delete to delete the files permanently.\n", err)
+			return
+		}
+		if saveErr := store.SaveGame(gameItem); saveErr != nil {
+			fmt.Printf("Error saving game: %v\n", saveErr)
+		}
+		fmt.Printf("Error uninstalling %s: %v\n", gameItem.Name, err)
+		return
+	}
+
+	steamManager := steam.NewManager()
+	if exists, err := steamManager.CheckGameExistsInSteam(gameItem); err == nil && exists {
+		if _, err := steamManager.RemoveGameFromSteam(gameItem); err != nil {
+			fmt.Printf("Warning: Failed to remove Steam shortcut: %v\n", err)
+		} else {
+			fmt.Println("Removed the Steam shortcut; restart Steam to see the change.")
+		}
+	}
+	if opts.KeepSaves {
+		if err := store.SaveGame(gameItem); err != nil {
+			fmt.Printf("Error saving game: %v\n", err)
+			return
+		}
+		fmt.Printf("Uninstalled %s, keeping it in the library as not installed\n", gameItem.Name)
+		return
+	}
+
+	if err := manager.DeleteImage(gameItem, games); err != nil {
+		fmt.Printf("Warning: Failed to delete image: %v\n", err)
+	}
+	if err := manager.DeleteVersionHistory(gameItem.ID); err != nil {
+		fmt.Printf("Warning: Failed to delete version history: %v\n", err)
+	}
+	if err := manager.DeleteSessions(gameItem.ID); err != nil {
+		fmt.Printf("Warning: Failed to delete play sessions: %v\n", err)
+	}
+	if err := store.DeleteGame(gameItem.ID); err != nil {
+		fmt.Printf("Error removing game from the library: %v\n", err)
+		return
+	}
+	fmt.Printf("Uninstalled %s\n", gameItem.Name)
+}
+
+// showDiskUsage prints the disk space taken by every game, largest first or
+// by name. Cached sizes are used unless --refresh is given.
+func showDiskUsage(options []string) {
+	order := game.UsageBySize
+	refresh := false
+	for _, option := range options {
+		switch option {
+		case "-refresh", "--refresh":
+			refresh = true
+		case game.UsageBySize, game.UsageByName:
+			order = option
+		default:
+			fmt.Printf("Unknown usage option: %s (sort by %s)\n", option, strings.Join(game.UsageOrders(), " or "))
+			return
+		}
+	}
+
+	store, err := openStore(storage.NewManager(dataDir))
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
+	if err != nil {
+		fmt.Printf("Error loading games: %v\n", err)
+		return
+	}
+	if len(games) == 0 {
+		fmt.Println("No games found.")
+		return
+	}
+
+	numbers := make(map[string]int, len(games))
+	for i, gameItem := range games {
+		numbers[gameItem.ID] = i + 1
+	}
+
+	gameManager := game.NewManager()
+	changed, err := gameManager.UpdateDiskUsage(games, refresh)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	if changed {
+		if _, err := storage.SaveAll(store, games); err != nil {
+			fmt.Printf("Warning: Failed to cache disk usage: %v\n", err)
+		}
+	}
+
+	fmt.Println("Disk usage:")
+	fmt.Println("===========")
+	for _, gameItem := range game.SortByDiskUsage(games, order) {
+		size := models.FormatSize(gameItem.DiskUsage)
+		if !gameItem.IsInstalled && gameItem.DiskUsage == 0 {
+			size = "not installed"
+		}
+		fmt.Printf("%10s  %d. %s\n", size, numbers[gameItem.ID], gameItem.Name)
+	}
+	fmt.Println()
+	fmt.Printf("Total: %s in %d games\n", models.FormatSize(game.TotalDiskUsage(games)), len(games))
+}
+
+// recordSession stores a finished play session and adds it to the game's
+// playtime. The game is reloaded first so changes made while playing, e.g.
+// in the GUI, are kept.
+func recordSession(manager *storage.Manager, store storage.GameStore, gameID string, session models.Session) {
+	if err := manager.RecordSession(gameID, session); err != nil {
+		fmt.Printf("Warning: Failed to record play session: %v\n", err)
+	}
+
+	games, err := loadGames(store)
+	if err != nil {
+		fmt.Printf("Warning: Failed to update playtime: %v\n", err)
+		return
+	}
+	for _, gameItem := range games {
+		if gameItem.ID == gameID {
+			gameItem.AddSession(session)
+			if err := store.SaveGame(gameItem); err != nil {
+				fmt.Printf("Warning: Failed to update playtime: %v\n", err)
+			}
+			return
+		}
+	}
+}
+
+// showPlaytimeStats prints the playtime of every played game, most played first
+func showPlaytimeStats() {
+	manager := storage.NewManager(dataDir)
+	store, err := openStore(manager)
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
+	if err != nil {
+		fmt.Printf("Error loading games: %v\n", err)
+		return
 	}
+
+	var played []*models.Game
+	var total time.Duration
+	for _, gameItem := range games {
+		if gameItem.PlaytimeSeconds > 0 || !gameItem.LastPlayed.IsZero() {
+			played = append(played, gameItem)
+			total += gameItem.Playtime()
+		}
+	}
+	if len(played) == 0 {
+		fmt.Println("No games played yet.")
+		return
+	}
+	sort.SliceStable(played, func(i, j int) bool {
+		return played[i].PlaytimeSeconds > played[j].PlaytimeSeconds
+	})
+
+	fmt.Println("Playtime:")
+	fmt.Println("=========")
+	for _, gameItem := range played {
+		fmt.Printf("%-10s %s\n", models.FormatPlaytime(gameItem.Playtime()), gameItem.Name)
+
+		details := []string{"last played " + gameItem.LastPlayed.Format("2006-01-02 15:04")}
+		if sessions, err := manager.Sessions(gameItem.ID); err == nil && len(sessions) > 0 {
+			average := gameItem.Playtime() / time.Duration(len(sessions))
+			details = append(details, fmt.Sprintf("%d session(s)", len(sessions)),
+				"average "+models.FormatPlaytime(average))
+		}
+		fmt.Printf("%-10s %s\n", "", strings.Join(details, ", "))
+	}
+	fmt.Printf("\nTotal: %s across %d games\n", models.FormatPlaytime(total), len(played))
 }
 
 // listGames lists all available games
-func listGames() {
-	storage := storage.NewManager()
-	games, err := storage.LoadGames()
+func listGames(query storage.GameQuery) {
+	store, err := openStore(storage.NewManager(dataDir))
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
 	if err != nil {
 		fmt.Printf("Error loading games: %v\n", err)
 		return
 	}
 
+	// Games keep the number used by -game and -steam when filtered
+	numbers := make(map[string]int, len(games))
+	for i, gameItem := range games {
+		numbers[gameItem.ID] = i + 1
+	}
+
+	if query != (storage.GameQuery{}) {
+		games, err = store.Query(query)
+		if err != nil {
+			fmt.Printf("Error querying games: %v\n", err)
+			return
+		}
+	}
+
 	if len(games) == 0 {
 		fmt.Println("No games found.")
 		return
@@ -130,19 +574,35 @@ func listGames() {
 
 	fmt.Println("Available games:")
 	fmt.Println("================")
-	for i, gameItem := range games {
-		fmt.Printf("%d. %s\n", i+1, gameItem.Name)
+	for _, gameItem := range games {
+		fmt.Printf("%d. %s\n", numbers[gameItem.ID], gameItem.Name)
 		fmt.Printf("   Executable: %s\n", gameItem.Executable)
+		if !gameItem.IsInstalled {
+			fmt.Printf("   Not installed (executable missing)\n")
+		}
 		if gameItem.CurrentVersion != "" {
 			fmt.Printf("   Version: %s\n", gameItem.CurrentVersion)
 		}
+		if gameItem.LastErrorKind != "" {
+			fmt.Printf("   Last check: %s\n", monitor.ErrorKind(gameItem.LastErrorKind).Label())
+		}
+		if len(gameItem.Tags) > 0 {
+			fmt.Printf("   Tags: %s\n", strings.Join(gameItem.Tags, ", "))
+		}
+		if runner := game.ResolveRunner(gameItem); runner != game.RunnerNative {
+			fmt.Printf("   Runner: %s\n", runner)
+		}
+		if gameItem.PlaytimeSeconds > 0 {
+			fmt.Printf("   Playtime: %s (last played %s)\n", models.FormatPlaytime(gameItem.Playtime()),
+				gameItem.LastPlayed.Format("2006-01-02"))
+		}
 		fmt.Println()
 	}
 }
 
 // searchForGame searches for a game on F95Zone and displays the results
 func searchForGame(gameName string) {
-	searchManager := search.NewManager()
+	searchManager := search.NewManager(dataDir)
 
 	fmt.Printf("Searching for '%s' on F95Zone...\n", gameName)
 
@@ -164,6 +624,20 @@ func searchForGame(gameName string) {
 		score := fmt.Sprintf("%.1f%%", result.MatchScore*100)
 		fmt.Printf("%d. [%s] %s\n", i+1, score, result.Title)
 		fmt.Printf("   Link: %s\n", result.Link)
+		var details []string
+		for _, field := range [][2]string{
+			{"Version", result.Version},
+			{"Developer", result.Developer},
+			{"Engine", result.Engine},
+			{"Status", result.Status},
+		} {
+			if field[1] != "" {
+				details = append(details, fmt.Sprintf("%s: %s", field[0], field[1]))
+			}
+		}
+		if len(details) > 0 {
+			fmt.Printf("   %s\n", strings.Join(details, ", "))
+		}
 		if result.Description != "" {
 			fmt.Printf("   Description: %s\n", result.Description)
 		}
@@ -187,8 +661,12 @@ func searchForGame(gameName string) {
 // addGameToSteamByNumber adds a game to Steam by its number in the list
 func addGameToSteamByNumber(gameNumber string) {
 	// Load games from storage
-	storage := storage.NewManager()
-	games, err := storage.LoadGames()
+	store, err := openStore(storage.NewManager(dataDir))
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
 	if err != nil {
 		fmt.Printf("Error loading games: %v\n", err)
 		return
@@ -210,7 +688,7 @@ func addGameToSteamByNumber(gameNumber string) {
 	index := num - 1
 	if index < 0 || index >= len(games) {
 		fmt.Printf("Game number %d not found. Available games:\n", num)
-		listGames()
+		listGames(storage.GameQuery{})
 		return
 	}
 
@@ -260,6 +738,213 @@ func addGameToSteamByNumber(gameNumber string) {
 	}
 }
 
+// checkForUpdates checks every game with a source URL and prints the results
+func checkForUpdates() {
+	manager := storage.NewManager(dataDir)
+	store, err := openStore(manager)
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
+	if err != nil {
+		fmt.Printf("Error loading games: %v\n", err)
+		return
+	}
+
+	settings, err := manager.LoadSettings()
+	if err != nil {
+		fmt.Printf("Warning: Could not load settings, using defaults: %v\n", err)
+		settings = models.DefaultSettings()
+	}
+
+	// Stop cleanly on Ctrl+C, keeping whatever was checked so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := monitor.DefaultCheckerOptions()
+	opts.Workers = settings.CheckWorkers
+	opts.HostRate = settings.CheckRatePerHost
+	checker := monitor.NewChecker(monitor.NewSourceMonitor(dataDir), opts)
+
+	updates := 0
+	for result := range checker.Run(ctx, games) {
+		prefix := fmt.Sprintf("[%d/%d] %s", result.Done, result.Total, result.Game.Name)
+		if result.Err != nil {
+			fmt.Printf("%s: %s: %v\n", prefix, monitor.KindOf(result.Err).Label(), result.Err)
+			if ctx.Err() == nil {
+				result.Game.MarkCheckFailed(string(monitor.KindOf(result.Err)), result.Err.Error())
+			}
+			continue
+		}
+
+		result.Game.UpdateInfo(result.Info.Version)
+		result.Game.UpdateSourceStatus(result.Info.Status)
+		result.Game.MarkChecked()
+		if result.Info.Version != "" {
+			record := models.VersionRecord{
+				Version:   result.Info.Version,
+				FirstSeen: time.Now(),
+				SourceURL: result.Game.SourceURL,
+				Changelog: result.Info.Changelog,
+			}
+			if _, err := manager.RecordVersion(result.Game.ID, record); err != nil {
+				fmt.Printf("Warning: Failed to record version history: %v\n", err)
+			}
+		}
+
+		if result.Info.StatusChanged {
+			fmt.Printf("%s: thread marked %s (was %s)\n", prefix, result.Info.Status, result.Info.PreviousStatus)
+		}
+
+		if result.Info.HasUpdate {
+			updates++
+			fmt.Printf("%s: update available %s -> %s\n", prefix, result.Game.CurrentVersion, result.Info.Version)
+			if result.Info.Changelog != "" {
+				for _, line := range strings.Split(result.Info.ChangelogExcerpt(10), "\n") {
+					fmt.Printf("    %s\n", line)
+				}
+			}
+		} else {
+			fmt.Printf("%s: up to date (%s)\n", prefix, result.Info.Version)
+		}
+	}
+
+	if ctx.Err() != nil {
+		fmt.Println("Check interrupted.")
+	}
+	fmt.Printf("%d update(s) available.\n", updates)
+
+	if _, err := storage.SaveAll(store, games); err != nil {
+		fmt.Printf("Error saving games: %v\n", err)
+	}
+}
+
+// exportLibrary writes the library to a .zip bundle, .csv or .md file
+func exportLibrary(filePath string) {
+	manager := storage.NewManager(dataDir)
+	store, err := openStore(manager)
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
+	if err != nil {
+		fmt.Printf("Error loading games: %v\n", err)
+		return
+	}
+	settings, err := manager.LoadSettings()
+	if err != nil {
+		fmt.Printf("Warning: Not exporting settings: %v\n", err)
+		settings = nil
+	}
+
+	if err := manager.Export(filePath, games, settings); err != nil {
+		fmt.Printf("Error exporting library: %v\n", err)
+		return
+	}
+	fmt.Printf("Exported %d games to %s\n", len(games), filePath)
+}
+
+// importLibrary adds the games of a .zip bundle, .csv file or games.json to
+// the library. Options: --map FROM=TO (repeatable) rewrites paths, --settings
+// also imports the settings of a bundle.
+func importLibrary(filePath string, options []string) {
+	var opts storage.ImportOptions
+	for i := 0; i < len(options); i++ {
+		switch options[i] {
+		case "-map", "--map":
+			if i+1 >= len(options) {
+				fmt.Println("Error: --map requires FROM=TO")
+				return
+			}
+			i++
+			mapping, err := storage.ParsePathMapping(options[i])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			opts.PathMappings = append(opts.PathMappings, mapping)
+		case "-settings", "--settings":
+			opts.Settings = true
+		default:
+			fmt.Printf("Unknown import option: %s\n", options[i])
+			showUsage()
+			return
+		}
+	}
+
+	manager := storage.NewManager(dataDir)
+	store, err := openStore(manager)
+	if err != nil {
+		fmt.Printf("Error opening game library: %v\n", err)
+		return
+	}
+	games, err := loadGames(store)
+	if err != nil {
+		fmt.Printf("Error loading games: %v\n", err)
+		return
+	}
+
+	result, err := manager.Import(filePath, games, opts)
+	if err != nil {
+		fmt.Printf("Error importing library: %v\n", err)
+		return
+	}
+
+	for _, duplicate := range result.Duplicates {
+		fmt.Printf("Skipping %s: already in the library\n", duplicate.Name)
+	}
+	for _, game := range result.Games {
+		fmt.Printf("Importing %s\n", game.Name)
+	}
+
+	if len(result.Games) > 0 {
+		if _, err := storage.SaveAll(store, append(games, result.Games...)); err != nil {
+			fmt.Printf("Error saving games: %v\n", err)
+			return
+		}
+	}
+
+	if result.Settings != nil {
+		current, err := manager.LoadSettings()
+		if err == nil {
+			// The backend describes this data directory, not the exported one
+			result.Settings.StorageBackend = current.StorageBackend
+		}
+		if err := manager.SaveSettings(result.Settings); err != nil {
+			fmt.Printf("Error saving settings: %v\n", err)
+		} else {
+			fmt.Println("Imported settings.")
+		}
+	}
+
+	fmt.Printf("Imported %d games, skipped %d duplicates.\n", len(result.Games), len(result.Duplicates))
+}
+
+// openStore opens the game library with the backend chosen in the settings
+func openStore(m *storage.Manager) (storage.GameStore, error) {
+	settings, err := m.LoadSettings()
+	if err != nil {
+		fmt.Printf("Warning: Could not load settings, using defaults: %v\n", err)
+		settings = models.DefaultSettings()
+	}
+	m.SetBackupCount(settings.BackupCount)
+	return storage.OpenGameStore(m, settings.StorageBackend)
+}
+
+// loadGames loads the library, warning about a restore from backup instead
+// of failing
+func loadGames(store storage.GameStore) ([]*models.Game, error) {
+	games, err := store.LoadGames()
+	var recovered *storage.RecoveryError
+	if errors.As(err, &recovered) {
+		fmt.Printf("Warning: %v\n", recovered)
+		return games, nil
+	}
+	return games, err
+}
+
 // showUsage displays command-line usage information
 func showUsage() {
 	fmt.Println("Game Launcher - Command Line Usage")
@@ -269,16 +954,46 @@ func showUsage() {
 	fmt.Println("  gamelauncher.exe")
 	fmt.Println()
 	fmt.Println("Command Line Options:")
-	fmt.Println("  -game <number>     Launch game by number")
-	fmt.Println("  -list              List all available games")
+	fmt.Println("  -game <number>     Launch game by number and record the play session")
+	fmt.Println("  -list [text]       List all games, or those whose name contains text")
+	fmt.Println("  -tag <tag>         List games with the given tag")
 	fmt.Println("  -search <name>     Search for game on F95Zone")
 	fmt.Println("  -steam <number>    Add game to Steam by number")
+	fmt.Println("  -check             Check all games for updates")
+	fmt.Println("  -install <number> <archive> [--no-saves]")
+	fmt.Println("                     Install a downloaded .zip, .tar or .7z as the game's new")
+	fmt.Println("                     version, keeping the old one for rollback")
+	fmt.Println("  -uninstall <number> [--delete] [--no-saves] [--yes]")
+	fmt.Println("                     Move the game's folders to the trash (or delete them)")
+	fmt.Println("                     and mark it not installed, backing up its saves; with")
+	fmt.Println("                     --no-saves the game and its saves are removed entirely")
+	fmt.Println("  -usage [size|name] [--refresh]")
+	fmt.Println("                     Show the disk space taken by each game")
+	fmt.Println("  -stats             Show playtime per game")
+	fmt.Println("  -export <file>     Export the library to a .zip bundle, .csv or .md report")
+	fmt.Println("  -import <file> [--map FROM=TO]... [--settings]")
+	fmt.Println("                     Import games from a .zip bundle, .csv or games.json,")
+	fmt.Println("                     rewriting paths starting with FROM to TO")
 	fmt.Println("  -help              Show this help message")
 	fmt.Println()
+	fmt.Println("Global Options:")
+	fmt.Println("  --data-dir <dir>   Keep library, settings and images in dir")
+	fmt.Println("                     (also set by the GAMELAUNCHER_HOME environment variable;")
+	fmt.Println("                     a gamelauncher.portable file next to the executable")
+	fmt.Println("                     keeps data in a data directory beside it)")
+	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  gamelauncher.exe -game 1        # Launch the first game")
 	fmt.Println("  gamelauncher.exe -list          # List all games")
+	fmt.Println("  gamelauncher.exe -tag favorite  # List games tagged favorite")
 	fmt.Println("  gamelauncher.exe -search \"My Pig Princess\"  # Search for a game")
 	fmt.Println("  gamelauncher.exe -steam 1       # Add first game to Steam")
+	fmt.Println("  gamelauncher.exe -check         # Check all games for updates")
+	fmt.Println("  gamelauncher.exe -install 1 Game-0.8-pc.zip  # Update the first game")
+	fmt.Println("  gamelauncher.exe -usage         # Show the largest games first")
+	fmt.Println("  gamelauncher.exe -uninstall 1   # Move the first game to the trash")
+	fmt.Println("  gamelauncher.exe -export library.zip  # Back up games, settings and images")
+	fmt.Println("  gamelauncher.exe -import library.zip --map D:\\Games=/mnt/games")
+	fmt.Println("  gamelauncher.exe --data-dir D:\\Games\\launcher -list  # Use another library")
 	fmt.Println("  gamelauncher.exe -help          # Show help")
 }
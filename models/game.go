@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,11 +21,44 @@ type Game struct {
 	IconPath    string    `json:"icon_path"`
 	ImagePath   string    `json:"image_path"` // Path to downloaded game image
 	IsInstalled bool      `json:"is_installed"`
+	Tags        []string  `json:"tags,omitempty"`   // User defined labels such as "favorite"
+	Engine      string    `json:"engine,omitempty"` // Detected engine such as "Ren'Py", empty if unknown
+
+	// Launch configuration
+	AltExecutables []string `json:"alt_executables,omitempty"` // Other programs found in the game folder, e.g. a 32-bit build
+	Fingerprint    string   `json:"fingerprint,omitempty"`     // Hash of the game's data files, recognises the game after its folder was moved or renamed
+	LaunchArgs     []string `json:"launch_args,omitempty"`     // Arguments passed to the executable
+	LaunchEnv      []string `json:"launch_env,omitempty"`      // Extra environment variables as KEY=VALUE
+	WorkingDir     string   `json:"working_dir,omitempty"`     // Overrides Folder as the working directory
+	Runner         string   `json:"runner,omitempty"`          // "native", "wine" or "proton"; empty picks one from the executable
+	WinePrefix     string   `json:"wine_prefix,omitempty"`     // Wine/Proton prefix, default if empty
+	Wrapper        []string `json:"wrapper,omitempty"`         // Command the game is run through, e.g. gamemoderun
+	SaveDirs       []string `json:"save_dirs,omitempty"`       // Save folders, detected from the engine if empty
 
 	// Version checking configuration
 	VersionSelector string `json:"version_selector"` // CSS selector for version element
 	VersionPattern  string `json:"version_pattern"`  // Regex pattern to extract version
 	CurrentVersion  string `json:"current_version"`  // Current version for comparison
+
+	IncludePrereleases bool   `json:"include_prereleases"`     // Consider prereleases when checking release-based sources
+	SourceStatus       string `json:"source_status,omitempty"` // Development status reported by the source, e.g. "Completed"
+
+	// Side-by-side installations, newest first, excluding the current one
+	InstalledVersions []InstalledVersion `json:"installed_versions,omitempty"`
+	InstalledAt       time.Time          `json:"installed_at"` // When the current version was installed by the launcher
+
+	// Disk space taken by the game folder and the kept installations, cached
+	// because summing large folders is slow
+	DiskUsage   int64     `json:"disk_usage,omitempty"` // In bytes
+	DiskUsageAt time.Time `json:"disk_usage_at"`        // When DiskUsage was measured, zero if never
+
+	// Playtime tracking, updated when a launched game exits
+	PlaytimeSeconds int64     `json:"playtime_seconds,omitempty"` // Total time played
+	LastPlayed      time.Time `json:"last_played"`                // When the last session ended
+
+	// Result of the most recent failed update check, cleared on success
+	LastErrorKind string `json:"last_error_kind,omitempty"` // e.g. "not_found", "rate_limited", "network"
+	LastError     string `json:"last_error,omitempty"`      // Human readable error message
 }
 
 // NewGame creates a new game instance with a unique ID
@@ -40,13 +74,61 @@ func NewGame(name, executable, folder string) *Game {
 	}
 }
 
+// LaunchDir returns the directory the game is started in
+func (g *Game) LaunchDir() string {
+	if g.WorkingDir != "" {
+		return g.WorkingDir
+	}
+	return g.Folder
+}
+
 // UpdateInfo updates the game's update information
 func (g *Game) UpdateInfo(version string) {
 	g.Version = version
 	g.LastUpdate = time.Now()
 }
 
-// MarkChecked updates the last check time
+// HasTag reports whether the game carries tag, ignoring case
+func (g *Game) HasTag(tag string) bool {
+	for _, t := range g.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateSourceStatus records the development status reported by the source.
+// Empty statuses are ignored so unknown never overwrites a known status.
+func (g *Game) UpdateSourceStatus(status string) {
+	if status != "" {
+		g.SourceStatus = status
+	}
+}
+
+// Playtime returns the total time the game was played
+func (g *Game) Playtime() time.Duration {
+	return time.Duration(g.PlaytimeSeconds) * time.Second
+}
+
+// AddSession adds a finished session to the playtime and last-played time
+func (g *Game) AddSession(session Session) {
+	g.PlaytimeSeconds += int64(session.Duration() / time.Second)
+	if session.End.After(g.LastPlayed) {
+		g.LastPlayed = session.End
+	}
+}
+
+// MarkChecked updates the last check time and clears any previous check error
 func (g *Game) MarkChecked() {
 	g.LastCheck = time.Now()
+	g.LastErrorKind = ""
+	g.LastError = ""
+}
+
+// MarkCheckFailed records why the most recent update check failed
+func (g *Game) MarkCheckFailed(kind, message string) {
+	g.LastCheck = time.Now()
+	g.LastErrorKind = kind
+	g.LastError = message
 }
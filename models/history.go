@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// VersionRecord is a version observed at a game's source
+type VersionRecord struct {
+	Version   string    `json:"version"`
+	FirstSeen time.Time `json:"first_seen"`
+	SourceURL string    `json:"source_url"`
+	Changelog string    `json:"changelog,omitempty"` // Release notes found alongside the version, if any
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// InstalledVersion is an earlier installation of a game kept on disk so the
+// game can be rolled back to it
+type InstalledVersion struct {
+	Version     string    `json:"version"`
+	Folder      string    `json:"folder"`
+	Executable  string    `json:"executable"`
+	InstalledAt time.Time `json:"installed_at"`
+	Managed     bool      `json:"managed,omitempty"` // Folder was created by the launcher; only these are pruned
+}
+
+// FormatSize formats a size in bytes for display, e.g. "3.4 MB"
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -8,6 +8,31 @@ type Settings struct {
 	StartMinimized bool   `json:"start_minimized"`
 	Theme          string `json:"theme"`
 	LastUsedPath   string `json:"last_used_path"` // Last used directory path for file dialogs
+
+	// Update checking
+	CheckWorkers     int     `json:"check_workers"`       // Number of games checked in parallel
+	CheckRatePerHost float64 `json:"check_rate_per_host"` // Requests per second allowed per source host
+
+	// Compatibility runners (Linux)
+	WinePath   string `json:"wine_path"`   // Wine binary, "wine" from PATH if empty
+	ProtonPath string `json:"proton_path"` // The proton script of a Proton installation
+
+	// Folder scanning
+	LibraryRoots []string `json:"library_roots"`  // Folders watched for added, moved and deleted games
+	ScanIgnore   []string `json:"scan_ignore"`    // Glob patterns of files and folders skipped when scanning
+	ScanMaxDepth int      `json:"scan_max_depth"` // Folder levels searched below the scanned folder, 0 for no limit
+
+	// Installing updates
+	DownloadsDir string `json:"downloads_dir"` // Folder searched for downloaded update archives
+	KeepVersions int    `json:"keep_versions"` // Older versions kept for rollback after installing an update
+	MigrateSaves bool   `json:"migrate_saves"` // Copy saves into newly installed versions
+
+	// Save backups
+	SaveSnapshots int `json:"save_snapshots"` // Save snapshots kept per game, 0 disables automatic ones
+
+	// Storage
+	BackupCount    int    `json:"backup_count"`    // Number of games.json backups to keep, 0 disables them
+	StorageBackend string `json:"storage_backend"` // "json" or "sqlite"
 }
 
 // DefaultSettings returns default application settings
@@ -19,5 +44,19 @@ func DefaultSettings() *Settings {
 		StartMinimized: false,
 		Theme:          "light",
 		LastUsedPath:   "", // Will be set to user's home directory on first use
+
+		CheckWorkers:     4,
+		CheckRatePerHost: 0.5,
+
+		ScanIgnore:   []string{".*", "__MACOSX", "_CommonRedist", "*redist*", "DirectX"},
+		ScanMaxDepth: 5,
+
+		KeepVersions: 2,
+		MigrateSaves: true,
+
+		SaveSnapshots: 10,
+
+		BackupCount:    5,
+		StorageBackend: "json",
 	}
 }
@@ -0,0 +1,188 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNotModified is returned for requests whose response has not changed
+// since the previous check, either because the server answered 304 or because
+// the body is byte-identical to the last one seen.
+var ErrNotModified = errors.New("source not modified since last check")
+
+// cacheEntry holds the validators remembered for a single URL
+type cacheEntry struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ContentHash  string    `json:"content_hash,omitempty"`
+	CheckedAt    time.Time `json:"checked_at"`
+}
+
+// ResponseCache persists HTTP validators and content hashes per URL so
+// unchanged source pages can be skipped without parsing them again.
+type ResponseCache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// NewResponseCache loads the cache stored at path. A missing or unreadable
+// file starts an empty cache.
+func NewResponseCache(path string) *ResponseCache {
+	c := &ResponseCache{
+		path:    path,
+		entries: make(map[string]*cacheEntry),
+	}
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &c.entries); err != nil {
+			fmt.Printf("Warning: Ignoring corrupt HTTP cache %s: %v\n", path, err)
+			c.entries = make(map[string]*cacheEntry)
+		}
+	}
+
+	return c
+}
+
+// cacheFile is the name of the cache inside the launcher's data directory
+const cacheFile = "http_cache.json"
+
+// lookup returns a copy of the entry for url, if any
+func (c *ResponseCache) lookup(url string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[url]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	return *entry, true
+}
+
+// store records the entry for url and writes the cache to disk
+func (c *ResponseCache) store(url string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[url] = &entry
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := writeCacheFile(c.path, data); err != nil {
+		fmt.Printf("Warning: Failed to save HTTP cache: %v\n", err)
+	}
+}
+
+// writeCacheFile replaces path with data through a temporary file in the same
+// directory, so concurrent launchers and crashes never see a partial cache.
+// It is not flushed to disk like the library; a lost cache only costs one
+// full fetch per source.
+func writeCacheFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+type bypassCacheKey struct{}
+
+// withoutCache returns a context whose requests skip the response cache
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// cachingTransport adds conditional request headers to GET requests and turns
+// unchanged responses into ErrNotModified.
+type cachingTransport struct {
+	base  http.RoundTripper
+	cache *ResponseCache
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Context().Value(bypassCacheKey{}) != nil {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	entry, cached := t.cache.lookup(key)
+
+	if cached && (entry.ETag != "" || entry.LastModified != "") {
+		req = req.Clone(req.Context())
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		entry.CheckedAt = time.Now()
+		t.cache.store(key, entry)
+		return nil, ErrNotModified
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+	unchanged := cached && entry.ContentHash == hash
+
+	t.cache.store(key, cacheEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ContentHash:  hash,
+		CheckedAt:    time.Now(),
+	})
+
+	if unchanged {
+		return nil, ErrNotModified
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
@@ -0,0 +1,243 @@
+package monitor
+
+import (
+	"context"
+	"gamelauncher/models"
+	"math/rand"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CheckerOptions configures how a Checker spreads requests over time
+type CheckerOptions struct {
+	Workers   int           // Number of games checked concurrently
+	HostRate  float64       // Sustained requests per second allowed per host
+	HostBurst int           // Requests allowed back-to-back before HostRate applies
+	Jitter    time.Duration // Upper bound of the random delay added before each request
+}
+
+// DefaultCheckerOptions returns conservative defaults that keep F95zone happy
+func DefaultCheckerOptions() CheckerOptions {
+	return CheckerOptions{
+		Workers:   4,
+		HostRate:  0.5,
+		HostBurst: 2,
+		Jitter:    500 * time.Millisecond,
+	}
+}
+
+// CheckResult is streamed by Checker.Run once per checked game
+type CheckResult struct {
+	Game  *models.Game
+	Info  *UpdateInfo
+	Err   error
+	Done  int // Number of games finished so far, including this one
+	Total int // Number of games being checked
+}
+
+// Checker runs update checks for many games in parallel while rate limiting
+// requests per host.
+type Checker struct {
+	monitor *SourceMonitor
+	opts    CheckerOptions
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewChecker creates a checker on top of a source monitor. Zero option values
+// are replaced with the defaults.
+func NewChecker(m *SourceMonitor, opts CheckerOptions) *Checker {
+	defaults := DefaultCheckerOptions()
+	if opts.Workers <= 0 {
+		opts.Workers = defaults.Workers
+	}
+	if opts.HostRate <= 0 {
+		opts.HostRate = defaults.HostRate
+	}
+	if opts.HostBurst <= 0 {
+		opts.HostBurst = defaults.HostBurst
+	}
+	if opts.Jitter < 0 {
+		opts.Jitter = 0
+	}
+
+	return &Checker{
+		monitor: m,
+		opts:    opts,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Run checks every game that has a source URL and streams the results. The
+// returned channel is closed once all games are done or ctx is cancelled;
+// games still queued at cancellation are not reported.
+func (c *Checker) Run(ctx context.Context, games []*models.Game) <-chan CheckResult {
+	var pending []*models.Game
+	for _, game := range games {
+		if game.SourceURL != "" {
+			pending = append(pending, game)
+		}
+	}
+
+	results := make(chan CheckResult)
+	jobs := make(chan *models.Game)
+
+	go func() {
+		defer close(jobs)
+		for _, game := range pending {
+			select {
+			case jobs <- game:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg      sync.WaitGroup
+		countMu sync.Mutex
+		done    int
+	)
+
+	workers := c.opts.Workers
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for game := range jobs {
+				info, err := c.check(ctx, game)
+
+				countMu.Lock()
+				done++
+				result := CheckResult{Game: game, Info: info, Err: err, Done: done, Total: len(pending)}
+				countMu.Unlock()
+
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// check waits for jitter, then runs a single check. Every request of the
+// check, retries included, waits for the host's rate limit.
+func (c *Checker) check(ctx context.Context, game *models.Game) (*UpdateInfo, error) {
+	if c.opts.Jitter > 0 {
+		delay := time.Duration(rand.Int63n(int64(c.opts.Jitter)))
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = withRateLimit(ctx, c.bucketFor(game.SourceURL))
+	return c.monitor.CheckForUpdatesContext(ctx, game)
+}
+
+// bucketFor returns the shared token bucket of the URL's host
+func (c *Checker) bucketFor(sourceURL string) *tokenBucket {
+	host := sourceURL
+	if u, err := url.Parse(sourceURL); err == nil && u.Hostname() != "" {
+		host = strings.ToLower(u.Hostname())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bucket, ok := c.buckets[host]
+	if !ok {
+		bucket = newTokenBucket(c.opts.HostRate, c.opts.HostBurst)
+		c.buckets[host] = bucket
+	}
+	return bucket
+}
+
+// tokenBucket is a minimal token-bucket rate limiter
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // bucket capacity
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is cancelled
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+type rateLimitKey struct{}
+
+// withRateLimit returns a context whose source requests each take a token
+// from bucket first
+func withRateLimit(ctx context.Context, bucket *tokenBucket) context.Context {
+	return context.WithValue(ctx, rateLimitKey{}, bucket)
+}
+
+// waitRateLimit blocks until the rate limit of ctx, if it has one, allows
+// another request
+func waitRateLimit(ctx context.Context) error {
+	if bucket, ok := ctx.Value(rateLimitKey{}).(*tokenBucket); ok {
+		return bucket.wait(ctx)
+	}
+	return nil
+}
+
+// sleepContext sleeps for d or until ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
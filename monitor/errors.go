@@ -0,0 +1,195 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorKind classifies why a source check failed
+type ErrorKind string
+
+const (
+	ErrorNotFound     ErrorKind = "not_found"     // Thread or page was removed
+	ErrorRateLimited  ErrorKind = "rate_limited"  // Server answered 429
+	ErrorAuthRequired ErrorKind = "auth_required" // Login needed to view the source
+	ErrorChallenge    ErrorKind = "challenge"     // DDoS-guard or other HTML challenge page
+	ErrorParse        ErrorKind = "parse"         // Page could not be understood
+	ErrorNetwork      ErrorKind = "network"       // Connection problems and server errors
+)
+
+// Permanent reports whether retrying later is unlikely to help without the
+// user changing something (e.g. the source URL)
+func (k ErrorKind) Permanent() bool {
+	return k == ErrorNotFound || k == ErrorAuthRequired
+}
+
+// Retryable reports whether the retry policy should try again right away
+func (k ErrorKind) Retryable() bool {
+	return k == ErrorRateLimited || k == ErrorNetwork
+}
+
+// Label returns a short description suitable for the game list
+func (k ErrorKind) Label() string {
+	switch k {
+	case ErrorNotFound:
+		return "Source gone"
+	case ErrorRateLimited:
+		return "Rate limited"
+	case ErrorAuthRequired:
+		return "Login required"
+	case ErrorChallenge:
+		return "Blocked"
+	case ErrorParse:
+		return "Parse failed"
+	default:
+		return "Temporary failure"
+	}
+}
+
+// SourceError describes a failed source check
+type SourceError struct {
+	Kind       ErrorKind
+	URL        string
+	StatusCode int           // HTTP status, if a response was received
+	RetryAfter time.Duration // Delay requested by the server, if any
+	Err        error
+}
+
+func (e *SourceError) Error() string {
+	msg := string(e.Kind)
+	if e.StatusCode != 0 {
+		msg = fmt.Sprintf("%s (status %d)", msg, e.StatusCode)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.URL, msg)
+}
+
+func (e *SourceError) Unwrap() error { return e.Err }
+
+// KindOf returns the kind of a source check error. Errors that were not
+// classified by a provider are treated as network failures.
+func KindOf(err error) ErrorKind {
+	var srcErr *SourceError
+	if errors.As(err, &srcErr) {
+		return srcErr.Kind
+	}
+	return ErrorNetwork
+}
+
+// NewParseError wraps err as a parse failure for sourceURL
+func NewParseError(sourceURL string, err error) error {
+	return &SourceError{Kind: ErrorParse, URL: sourceURL, Err: err}
+}
+
+// NewNotFoundError reports that the source at sourceURL no longer exists
+func NewNotFoundError(sourceURL string, reason string) error {
+	return &SourceError{Kind: ErrorNotFound, URL: sourceURL, Err: errors.New(reason)}
+}
+
+// NewAuthRequiredError reports that sourceURL can only be viewed when logged in
+func NewAuthRequiredError(sourceURL string, reason string) error {
+	return &SourceError{Kind: ErrorAuthRequired, URL: sourceURL, Err: errors.New(reason)}
+}
+
+// challengeMarkers are found on anti-bot interstitial pages
+var challengeMarkers = []string{
+	"ddos-guard",
+	"cf-browser-verification",
+	"challenge-platform",
+	"checking your browser",
+	"just a moment...",
+	"attention required!",
+}
+
+// CheckResponse classifies a non-OK response as a typed SourceError. It
+// returns nil for 200 OK. The body is only peeked at, never consumed entirely.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	srcErr := &SourceError{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		srcErr.Kind = ErrorNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		srcErr.Kind = ErrorRateLimited
+		srcErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	case resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		// GitHub signals an exhausted API quota with 403 and a reset timestamp
+		srcErr.Kind = ErrorRateLimited
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			srcErr.RetryAfter = time.Until(time.Unix(reset, 0))
+		}
+	case isChallenge(resp):
+		srcErr.Kind = ErrorChallenge
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		srcErr.Kind = ErrorAuthRequired
+	default:
+		srcErr.Kind = ErrorNetwork
+	}
+
+	return srcErr
+}
+
+// isChallenge peeks at an error response to detect anti-bot challenge pages
+func isChallenge(resp *http.Response) bool {
+	if strings.Contains(strings.ToLower(resp.Header.Get("Server")), "ddos-guard") {
+		return true
+	}
+
+	peek, _ := io.ReadAll(io.LimitReader(resp.Body, 16*1024))
+	resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(peek), resp.Body))
+
+	lower := strings.ToLower(string(peek))
+	for _, marker := range challengeMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter understands both delta-seconds and HTTP-date values
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// classifyError turns any error returned by a provider into a *SourceError.
+// Errors caused by ctx being cancelled are returned unchanged.
+func classifyError(ctx context.Context, sourceURL string, err error) error {
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+
+	var srcErr *SourceError
+	if errors.As(err, &srcErr) {
+		return err
+	}
+	return &SourceError{Kind: ErrorNetwork, URL: sourceURL, Err: err}
+}
@@ -0,0 +1,108 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"gamelauncher/models"
+	"gamelauncher/version"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// GenericProvider scrapes arbitrary web pages using the game's configured CSS
+// selector and regex pattern. It is the fallback for URLs no other provider
+// claims.
+type GenericProvider struct{}
+
+var _ Provider = (*GenericProvider)(nil)
+
+func (p *GenericProvider) Name() string { return "generic" }
+
+// Match accepts any URL; the generic provider is only consulted as a fallback.
+func (p *GenericProvider) Match(sourceURL string) bool { return true }
+
+// Check performs generic web scraping for updates
+func (p *GenericProvider) Check(ctx context.Context, client *http.Client, game *models.Game) (*UpdateInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", game.SourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, NewParseError(game.SourceURL, err)
+	}
+
+	// Extract version using configured selector and pattern
+	found := p.extractVersionWithConfig(doc, game)
+
+	// If no version found and this is the first check, try to find any version
+	if found == "" && game.CurrentVersion == "" {
+		found = ExtractVersionFromPage(doc)
+		if found != "" {
+			// Store this as the current version for future comparisons
+			game.CurrentVersion = found
+		}
+	}
+
+	return &UpdateInfo{
+		HasUpdate:   version.IsNewer(found, game.CurrentVersion),
+		Version:     found,
+		URL:         game.SourceURL,
+		ReleaseDate: time.Now(),
+		Description: fmt.Sprintf("Current: %s, Found: %s", game.CurrentVersion, found),
+	}, nil
+}
+
+// extractVersionWithConfig extracts version using configured selector and pattern
+func (p *GenericProvider) extractVersionWithConfig(doc *goquery.Document, game *models.Game) string {
+	// If no custom selector is configured, return empty
+	if game.VersionSelector == "" {
+		return ""
+	}
+
+	var foundVersion string
+
+	// Find elements matching the selector
+	doc.Find(game.VersionSelector).Each(func(i int, s *goquery.Selection) {
+		if foundVersion != "" {
+			return // Already found a version
+		}
+
+		text := strings.TrimSpace(s.Text())
+
+		// If a custom pattern is configured, use it
+		if game.VersionPattern != "" {
+			re, err := regexp.Compile(game.VersionPattern)
+			if err == nil {
+				matches := re.FindStringSubmatch(text)
+				if len(matches) > 1 {
+					foundVersion = matches[1] // Return the first capture group
+					return
+				}
+			}
+		}
+
+		// Otherwise, check if the text looks like a version
+		if IsVersionString(text) {
+			foundVersion = text
+			return
+		}
+	})
+
+	return foundVersion
+}
@@ -0,0 +1,42 @@
+package monitor
+
+import (
+	"context"
+	"gamelauncher/models"
+	"net/http"
+)
+
+// Provider is implemented by any package that knows how to check a particular
+// kind of source URL for game updates.
+type Provider interface {
+	Name() string
+
+	// Match reports whether the provider can handle the supplied source URL.
+	Match(sourceURL string) bool
+
+	// Check fetches the game's source page using client and reports what it
+	// found. Requests must be bound to ctx so callers can cancel them.
+	Check(ctx context.Context, client *http.Client, game *models.Game) (*UpdateInfo, error)
+}
+
+// global registry that providers populate from their init() functions.
+var registeredProviders []Provider
+
+// fallbackProvider is used when no registered provider matches a source URL.
+var fallbackProvider Provider = &GenericProvider{}
+
+// RegisterProvider is called by a provider's init() to make itself available.
+func RegisterProvider(p Provider) {
+	registeredProviders = append(registeredProviders, p)
+}
+
+// providerFor returns the first registered provider matching the URL, or the
+// generic CSS-selector provider if none does.
+func providerFor(providers []Provider, sourceURL string) Provider {
+	for _, p := range providers {
+		if p.Match(sourceURL) {
+			return p
+		}
+	}
+	return fallbackProvider
+}
@@ -0,0 +1,51 @@
+package monitor
+
+import (
+	"errors"
+	"time"
+)
+
+// RetryPolicy controls how often failed source checks are retried
+type RetryPolicy struct {
+	MaxAttempts   int           // Total attempts including the first one
+	BaseDelay     time.Duration // Delay before the first retry, doubled for each further retry
+	MaxDelay      time.Duration // Upper bound of the exponential backoff
+	MaxRetryAfter time.Duration // Give up instead of waiting longer than this for Retry-After
+}
+
+// DefaultRetryPolicy returns the policy used by NewSourceMonitor
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:   3,
+		BaseDelay:     2 * time.Second,
+		MaxDelay:      30 * time.Second,
+		MaxRetryAfter: 2 * time.Minute,
+	}
+}
+
+// nextDelay returns how long to wait before retrying after the given failed
+// attempt (1-based), or false if the error should not be retried
+func (p RetryPolicy) nextDelay(attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	var srcErr *SourceError
+	if !errors.As(err, &srcErr) || !srcErr.Kind.Retryable() {
+		return 0, false
+	}
+
+	// The server told us when to come back; honour it if it is reasonable
+	if srcErr.RetryAfter > 0 {
+		if srcErr.RetryAfter > p.MaxRetryAfter {
+			return 0, false
+		}
+		return srcErr.RetryAfter, true
+	}
+
+	delay := p.BaseDelay << (attempt - 1)
+	if delay > p.MaxDelay || delay <= 0 {
+		delay = p.MaxDelay
+	}
+	return delay, true
+}
@@ -1,9 +1,13 @@
 package monitor
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"gamelauncher/models"
+	"gamelauncher/version"
 	"net/http"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -13,225 +17,154 @@ import (
 
 // SourceMonitor monitors game sources for updates
 type SourceMonitor struct {
-	client *http.Client
+	client    *http.Client
+	providers []Provider
+	retry     RetryPolicy
 }
 
-// NewSourceMonitor creates a new source monitor
-func NewSourceMonitor() *SourceMonitor {
+// NewSourceMonitor creates a new source monitor using the registered providers.
+// Responses are cached in the launcher's data directory so unchanged pages
+// are not parsed again.
+func NewSourceMonitor(dataDir string) *SourceMonitor {
 	return &SourceMonitor{
 		client: &http.Client{
 			Timeout: 30 * time.Second,
+			Transport: &cachingTransport{
+				base:  http.DefaultTransport,
+				cache: NewResponseCache(filepath.Join(dataDir, cacheFile)),
+			},
 		},
+		providers: registeredProviders,
+		retry:     DefaultRetryPolicy(),
 	}
 }
 
-// CheckForUpdates checks if a game has updates available
-func (m *SourceMonitor) CheckForUpdates(game *models.Game) (*UpdateInfo, error) {
-	if game.SourceURL == "" {
-		return nil, fmt.Errorf("no source URL configured")
-	}
-
-	// Check F95zone URLs
-	if strings.Contains(game.SourceURL, "f95zone.to") {
-		return m.checkF95zoneSource(game)
-	}
-
-	// Generic web scraping for other sources
-	return m.checkGenericSource(game)
+// SetRetryPolicy replaces the policy used for transient check failures
+func (m *SourceMonitor) SetRetryPolicy(policy RetryPolicy) {
+	m.retry = policy
 }
 
-// UpdateInfo contains information about available updates
-type UpdateInfo struct {
-	HasUpdate   bool
-	Version     string
-	URL         string
-	ReleaseDate time.Time
-	Description string
+// CheckForUpdates checks if a game has updates available
+func (m *SourceMonitor) CheckForUpdates(game *models.Game) (*UpdateInfo, error) {
+	return m.CheckForUpdatesContext(context.Background(), game)
 }
 
-// checkF95zoneSource performs specialized scraping for F95zone game threads
-func (m *SourceMonitor) checkF95zoneSource(game *models.Game) (*UpdateInfo, error) {
-	resp, err := m.client.Get(game.SourceURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("F95zone returned status %d", resp.StatusCode)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, err
+// CheckForUpdatesContext is like CheckForUpdates but aborts when ctx is cancelled
+func (m *SourceMonitor) CheckForUpdatesContext(ctx context.Context, game *models.Game) (*UpdateInfo, error) {
+	if game.SourceURL == "" {
+		return nil, fmt.Errorf("no source URL configured")
 	}
 
-	// F95zone specific version extraction
-	version := m.extractF95zoneVersion(doc)
-
-	// If no version found and this is the first check, try generic extraction
-	if version == "" && game.CurrentVersion == "" {
-		version = m.extractVersionFromPage(doc)
-		if version != "" {
-			// Store this as the current version for future comparisons
-			game.CurrentVersion = version
+	provider := providerFor(m.providers, game.SourceURL)
+	info, err := m.checkWithRetry(ctx, provider, game)
+	if !errors.Is(err, ErrNotModified) {
+		if err == nil {
+			detectStatusChange(game, info)
 		}
+		return info, err
 	}
 
-	hasUpdate := false
-	if version != "" && version != game.CurrentVersion {
-		hasUpdate = true
-	}
-
-	return &UpdateInfo{
-		HasUpdate:   hasUpdate,
-		Version:     version,
-		URL:         game.SourceURL,
-		ReleaseDate: time.Now(),
-		Description: fmt.Sprintf("F95zone - Current: %s, Found: %s", game.CurrentVersion, version),
-	}, nil
-}
-
-// checkGenericSource performs generic web scraping for updates
-func (m *SourceMonitor) checkGenericSource(game *models.Game) (*UpdateInfo, error) {
-	resp, err := m.client.Get(game.SourceURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("source returned status %d", resp.StatusCode)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	// Extract version using configured selector and pattern
-	version := m.extractVersionWithConfig(doc, game)
-
-	// If no version found and this is the first check, try to find any version
-	if version == "" && game.CurrentVersion == "" {
-		version = m.extractVersionFromPage(doc)
-		if version != "" {
-			// Store this as the current version for future comparisons
-			game.CurrentVersion = version
+	// Nothing changed since the last check; reuse the version we already know.
+	// Without one (first check, or the configuration was changed) the page has
+	// to be parsed, so fetch it again bypassing the cache.
+	if game.Version == "" {
+		info, err := m.checkWithRetry(withoutCache(ctx), provider, game)
+		if err == nil {
+			detectStatusChange(game, info)
 		}
-	}
-
-	hasUpdate := false
-	if version != "" && version != game.CurrentVersion {
-		hasUpdate = true
+		return info, err
 	}
 
 	return &UpdateInfo{
-		HasUpdate:   hasUpdate,
-		Version:     version,
+		HasUpdate:   version.IsNewer(game.Version, game.CurrentVersion),
+		Version:     game.Version,
 		URL:         game.SourceURL,
-		ReleaseDate: time.Now(),
-		Description: fmt.Sprintf("Current: %s, Found: %s", game.CurrentVersion, version),
+		ReleaseDate: game.LastUpdate,
+		Description: fmt.Sprintf("Not modified - Current: %s, Found: %s", game.CurrentVersion, game.Version),
+		NotModified: true,
 	}, nil
 }
 
-// extractVersionWithConfig extracts version using configured selector and pattern
-func (m *SourceMonitor) extractVersionWithConfig(doc *goquery.Document, game *models.Game) string {
-	// If no custom selector is configured, return empty
-	if game.VersionSelector == "" {
-		return ""
-	}
-
-	var foundVersion string
-
-	// Find elements matching the selector
-	doc.Find(game.VersionSelector).Each(func(i int, s *goquery.Selection) {
-		if foundVersion != "" {
-			return // Already found a version
+// checkWithRetry runs the provider, retrying transient failures with backoff.
+// Every attempt waits for the rate limit of ctx.
+// Errors are returned as *SourceError unless ctx was cancelled.
+func (m *SourceMonitor) checkWithRetry(ctx context.Context, provider Provider, game *models.Game) (*UpdateInfo, error) {
+	for attempt := 1; ; attempt++ {
+		if err := waitRateLimit(ctx); err != nil {
+			return nil, err
 		}
 
-		text := strings.TrimSpace(s.Text())
+		info, err := provider.Check(ctx, m.client, game)
+		if err == nil || errors.Is(err, ErrNotModified) {
+			return info, err
+		}
 
-		// If a custom pattern is configured, use it
-		if game.VersionPattern != "" {
-			re, err := regexp.Compile(game.VersionPattern)
-			if err == nil {
-				matches := re.FindStringSubmatch(text)
-				if len(matches) > 1 {
-					foundVersion = matches[1] // Return the first capture group
-					return
-				}
-			}
+		err = classifyError(ctx, game.SourceURL, err)
+		delay, retry := m.retry.nextDelay(attempt, err)
+		if !retry {
+			return nil, err
 		}
 
-		// Otherwise, check if the text looks like a version
-		if m.isVersionString(text) {
-			foundVersion = text
-			return
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return nil, sleepErr
 		}
-	})
+	}
+}
 
-	return foundVersion
+// UpdateInfo contains information about available updates
+type UpdateInfo struct {
+	HasUpdate   bool
+	Version     string
+	URL         string
+	ReleaseDate time.Time
+	Description string
+	Changelog   string // Release notes for Version, if the source publishes them
+	NotModified bool   // Source was unchanged since the previous check
+
+	// Development status of the source (e.g. "Ongoing", "Completed"), if the
+	// provider can tell. StatusChanged is set by the monitor when the source
+	// switched to a final status since the previous check.
+	Status         string
+	PreviousStatus string
+	StatusChanged  bool
+
+	// Details from the source page, filled in by providers that understand them
+	ThreadUpdated time.Time
+	Developer     string
+	Censored      string
+	OS            []string
+	Languages     []string
 }
 
-// extractF95zoneVersion extracts version information specifically from F95zone game threads
-func (m *SourceMonitor) extractF95zoneVersion(doc *goquery.Document) string {
-	// F95zone specific selectors for version information
-	// Based on the page structure: "**Version**: 0.514.0.3 with RTP"
+// IsFinalStatus reports whether a source status means no further updates are
+// expected
+func IsFinalStatus(status string) bool {
+	return strings.EqualFold(status, "Completed") || strings.EqualFold(status, "Abandoned")
+}
 
-	// Look for version information in the game details section
-	selectors := []string{
-		"strong:contains('Version')", // **Version**: pattern
-		"b:contains('Version')",      // <b>Version</b> pattern
-		"[class*='version']",         // Any class containing 'version'
-		"[id*='version']",            // Any id containing 'version'
-		".message",                   // Forum message content
-		"#message-1",                 // First message (usually contains game info)
+// detectStatusChange flags a source that became completed or abandoned since
+// the status recorded on the game. The first status seen is never an event.
+func detectStatusChange(game *models.Game, info *UpdateInfo) {
+	if info.Status == "" || game.SourceStatus == "" || strings.EqualFold(info.Status, game.SourceStatus) {
+		return
 	}
+	info.PreviousStatus = game.SourceStatus
+	info.StatusChanged = IsFinalStatus(info.Status)
+}
 
-	var foundVersion string
-
-	for _, selector := range selectors {
-		if foundVersion != "" {
-			break
-		}
-
-		doc.Find(selector).Each(func(i int, s *goquery.Selection) {
-			if foundVersion != "" {
-				return
-			}
-
-			text := s.Text()
-
-			// Look for F95zone version patterns
-			// Pattern: "Version: X.X.X.X with RTP" or "Version: X.X.X.X" or "Version: X.X.X Steam" or "Version: X.X.X Final"
-			versionPatterns := []*regexp.Regexp{
-				regexp.MustCompile(`(?i)version[:\s]*([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
-				regexp.MustCompile(`(?i)version[:\s]*([0-9]+\.[0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
-				regexp.MustCompile(`(?i)version[:\s]*([0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
-				regexp.MustCompile(`(?i)v([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
-				regexp.MustCompile(`(?i)v([0-9]+\.[0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
-				// Additional patterns for Steam/Final in version strings
-				regexp.MustCompile(`(?i)([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)\s+(?:steam|final)`),
-				regexp.MustCompile(`(?i)([0-9]+\.[0-9]+\.[0-9]+)\s+(?:steam|final)`),
-				regexp.MustCompile(`(?i)([0-9]+\.[0-9]+)\s+(?:steam|final)`),
-			}
-
-			for _, pattern := range versionPatterns {
-				matches := pattern.FindStringSubmatch(text)
-				if len(matches) > 1 {
-					foundVersion = matches[1]
-					return
-				}
-			}
-		})
+// ChangelogExcerpt returns at most maxLines lines of the changelog, marking
+// where it was cut off
+func (u *UpdateInfo) ChangelogExcerpt(maxLines int) string {
+	lines := strings.Split(strings.TrimSpace(u.Changelog), "\n")
+	if len(lines) <= maxLines {
+		return strings.Join(lines, "\n")
 	}
-
-	return foundVersion
+	return strings.Join(lines[:maxLines], "\n") + "\n..."
 }
 
-// extractVersionFromPage tries to extract version information from a webpage
-func (m *SourceMonitor) extractVersionFromPage(doc *goquery.Document) string {
+// ExtractVersionFromPage tries to extract version information from a webpage.
+// Providers use it as a last resort when their site-specific selectors fail.
+func ExtractVersionFromPage(doc *goquery.Document) string {
 	// Look for common version patterns
 	selectors := []string{
 		"[class*='version']",
@@ -254,7 +187,7 @@ func (m *SourceMonitor) extractVersionFromPage(doc *goquery.Document) string {
 			}
 
 			text := strings.TrimSpace(s.Text())
-			if m.isVersionString(text) {
+			if IsVersionString(text) {
 				foundVersion = text
 				return
 			}
@@ -264,8 +197,8 @@ func (m *SourceMonitor) extractVersionFromPage(doc *goquery.Document) string {
 	return foundVersion
 }
 
-// isVersionString checks if a string looks like a version number
-func (m *SourceMonitor) isVersionString(s string) bool {
+// IsVersionString checks if a string looks like a version number
+func IsVersionString(s string) bool {
 	// Simple version pattern matching including Steam/Final versions
 	versionPatterns := []string{
 		"v\\d+\\.\\d+",
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"time"
 
+	"gamelauncher/datadir"
 	"gamelauncher/search"
 
 	"github.com/gocolly/colly/v2"
@@ -62,24 +63,27 @@ type Service struct {
 	imageDir   string
 }
 
-var _ search.Plugin = (*Service)(nil)
+var (
+	_ search.Plugin       = (*Service)(nil)
+	_ search.DataDirAware = (*Service)(nil)
+)
 
 func (s *Service) Name() string { return "f95zone" }
 
+// NewService creates the plugin; images are saved once SetDataDir is called
 func NewService() *Service {
-	home, _ := os.UserHomeDir()
-	if home == "" {
-		home = "."
-	}
-	imgDir := filepath.Join(home, ".gamelauncher", "images")
-	_ = os.MkdirAll(imgDir, 0755)
 	return &Service{
 		baseURL:    "https://f95zone.to/sam/latest_alpha/latest_data.php",
 		httpClient: &http.Client{Timeout: 30 * time.Second}, // Increased timeout for scraping
-		imageDir:   imgDir,
 	}
 }
 
+// SetDataDir stores downloaded images in the images directory of dataDir
+func (s *Service) SetDataDir(dataDir string) {
+	s.imageDir = datadir.ImagesDir(dataDir)
+	_ = os.MkdirAll(s.imageDir, 0755)
+}
+
 func init() { search.RegisterPlugin(NewService()) }
 
 // ---------------- core methods ----------------
@@ -102,7 +106,17 @@ func (s *Service) SearchGame(gameName string) ([]SearchResult, error) {
 	}
 	var results []SearchResult
 	for _, item := range rss.Channel.Items {
-		score := s.calculateMatchScore(gameName, item.Title)
+		title := ParseTitle(item.Title)
+		if title.Engine == "" {
+			title.Engine = EngineFromCategory(item.Category)
+		}
+
+		// Score against the bare name so versions and tags don't dilute it
+		name := title.Name
+		if name == "" {
+			name = item.Title
+		}
+		score := s.calculateMatchScore(gameName, name)
 		if score < 0.4 {
 			continue
 		}
@@ -114,6 +128,11 @@ func (s *Service) SearchGame(gameName string) ([]SearchResult, error) {
 			Category:    item.Category,
 			MatchScore:  score,
 			ImageURL:    "", // Intentionally blank, will be fetched from source
+			Name:        title.Name,
+			Version:     title.Version,
+			Developer:   title.Developer,
+			Engine:      title.Engine,
+			Status:      title.Status,
 		})
 	}
 	if len(results) == 0 && len(gameName) > 4 {
@@ -215,6 +234,9 @@ func (s *Service) downloadImageURL(imageURL string) (string, error) {
 	if imageURL == "" {
 		return "", fmt.Errorf("empty image url")
 	}
+	if s.imageDir == "" {
+		return "", fmt.Errorf("no image directory set")
+	}
 	if strings.HasPrefix(imageURL, "/") {
 		imageURL = "https://f95zone.to" + imageURL
 	}
@@ -0,0 +1,195 @@
+package f95zone
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"gamelauncher/models"
+	"gamelauncher/monitor"
+	"gamelauncher/version"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// UpdateProvider implements monitor.Provider for F95zone game threads
+type UpdateProvider struct{}
+
+var _ monitor.Provider = (*UpdateProvider)(nil)
+
+func (p *UpdateProvider) Name() string { return "f95zone" }
+
+func (p *UpdateProvider) Match(sourceURL string) bool {
+	return strings.Contains(sourceURL, "f95zone.to")
+}
+
+func init() { monitor.RegisterProvider(&UpdateProvider{}) }
+
+// Check performs specialized scraping for F95zone game threads
+func (p *UpdateProvider) Check(ctx context.Context, client *http.Client, game *models.Game) (*monitor.UpdateInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", game.SourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := monitor.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, monitor.NewParseError(game.SourceURL, err)
+	}
+
+	if err := p.checkErrorPage(game.SourceURL, doc); err != nil {
+		return nil, err
+	}
+
+	// F95zone specific version extraction
+	found := p.extractVersion(doc)
+
+	// If no version found and this is the first check, try generic extraction
+	if found == "" && game.CurrentVersion == "" {
+		found = monitor.ExtractVersionFromPage(doc)
+		if found != "" {
+			// Store this as the current version for future comparisons
+			game.CurrentVersion = found
+		}
+	}
+
+	details := ParseThreadDetails(doc)
+
+	var status string
+	if title, ok := p.threadTitle(doc); ok {
+		status = title.Status
+		if status == "" {
+			status = StatusOngoing
+		}
+	}
+
+	releaseDate := details.ReleaseDate
+	if releaseDate.IsZero() {
+		releaseDate = time.Now()
+	}
+
+	return &monitor.UpdateInfo{
+		HasUpdate:     version.IsNewer(found, game.CurrentVersion),
+		Version:       found,
+		URL:           game.SourceURL,
+		ReleaseDate:   releaseDate,
+		Description:   fmt.Sprintf("F95zone - Current: %s, Found: %s", game.CurrentVersion, found),
+		Changelog:     details.Changelog,
+		ThreadUpdated: details.ThreadUpdated,
+		Developer:     details.Developer,
+		Censored:      details.Censored,
+		OS:            details.OS,
+		Languages:     details.Languages,
+		Status:        status,
+	}, nil
+}
+
+// threadTitle parses the thread title, whose engine and status prefixes are
+// rendered as separate label elements in the page heading
+func (p *UpdateProvider) threadTitle(doc *goquery.Document) (ThreadTitle, bool) {
+	heading := doc.Find("h1.p-title-value").First()
+	if heading.Length() == 0 {
+		title := strings.TrimSpace(doc.Find("title").First().Text())
+		if title == "" {
+			return ThreadTitle{}, false
+		}
+		return ParseTitle(title), true
+	}
+
+	var prefixes []string
+	heading.Find(".label").Each(func(i int, s *goquery.Selection) {
+		prefixes = append(prefixes, "["+strings.TrimSpace(s.Text())+"]")
+	})
+
+	heading = heading.Clone()
+	heading.Find(".label, .label-append").Remove()
+	prefixes = append(prefixes, strings.TrimSpace(heading.Text()))
+
+	return ParseTitle(strings.Join(prefixes, " ")), true
+}
+
+// checkErrorPage detects XenForo error pages that are served with status 200,
+// such as removed threads or threads restricted to logged-in members
+func (p *UpdateProvider) checkErrorPage(sourceURL string, doc *goquery.Document) error {
+	if doc.Find("article.message-threadStarterPost").Length() > 0 {
+		return nil
+	}
+
+	message := strings.ToLower(strings.TrimSpace(doc.Find(".blockMessage").First().Text()))
+	switch {
+	case strings.Contains(message, "could not be found"), strings.Contains(message, "has been deleted"):
+		return monitor.NewNotFoundError(sourceURL, "thread no longer exists")
+	case strings.Contains(message, "must be logged-in"), strings.Contains(message, "do not have permission"):
+		return monitor.NewAuthRequiredError(sourceURL, "thread requires login")
+	}
+	return nil
+}
+
+// extractVersion extracts version information specifically from F95zone game threads
+func (p *UpdateProvider) extractVersion(doc *goquery.Document) string {
+	// F95zone specific selectors for version information
+	// Based on the page structure: "**Version**: 0.514.0.3 with RTP"
+
+	// Look for version information in the game details section
+	selectors := []string{
+		"strong:contains('Version')", // **Version**: pattern
+		"b:contains('Version')",      // <b>Version</b> pattern
+		"[class*='version']",         // Any class containing 'version'
+		"[id*='version']",            // Any id containing 'version'
+		".message",                   // Forum message content
+		"#message-1",                 // First message (usually contains game info)
+	}
+
+	var foundVersion string
+
+	for _, selector := range selectors {
+		if foundVersion != "" {
+			break
+		}
+
+		doc.Find(selector).Each(func(i int, s *goquery.Selection) {
+			if foundVersion != "" {
+				return
+			}
+
+			text := s.Text()
+
+			// Look for F95zone version patterns
+			// Pattern: "Version: X.X.X.X with RTP" or "Version: X.X.X.X" or "Version: X.X.X Steam" or "Version: X.X.X Final"
+			versionPatterns := []*regexp.Regexp{
+				regexp.MustCompile(`(?i)version[:\s]*([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
+				regexp.MustCompile(`(?i)version[:\s]*([0-9]+\.[0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
+				regexp.MustCompile(`(?i)version[:\s]*([0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
+				regexp.MustCompile(`(?i)v([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
+				regexp.MustCompile(`(?i)v([0-9]+\.[0-9]+\.[0-9]+)(?:\s+(?:with\s+rtp|steam|final))?`),
+				// Additional patterns for Steam/Final in version strings
+				regexp.MustCompile(`(?i)([0-9]+\.[0-9]+\.[0-9]+\.[0-9]+)\s+(?:steam|final)`),
+				regexp.MustCompile(`(?i)([0-9]+\.[0-9]+\.[0-9]+)\s+(?:steam|final)`),
+				regexp.MustCompile(`(?i)([0-9]+\.[0-9]+)\s+(?:steam|final)`),
+			}
+
+			for _, pattern := range versionPatterns {
+				matches := pattern.FindStringSubmatch(text)
+				if len(matches) > 1 {
+					foundVersion = matches[1]
+					return
+				}
+			}
+		})
+	}
+
+	return foundVersion
+}
@@ -0,0 +1,185 @@
+package f95zone
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// ThreadDetails holds the structured information F95zone developers put in
+// the header of a game thread's first post
+type ThreadDetails struct {
+	ThreadUpdated time.Time
+	ReleaseDate   time.Time
+	Developer     string
+	Censored      string
+	OS            []string
+	Languages     []string
+	Changelog     string
+}
+
+// headerFieldPattern matches "Label: value" lines of the first post header
+var headerFieldPattern = regexp.MustCompile(`^(Thread Updated|Release Date|Developer|Publisher|Censored|OS|Language)\s*:\s*(.+)$`)
+
+// dateLayouts lists the date formats seen in thread headers
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+}
+
+// ParseThreadDetails extracts the header fields and the changelog spoiler
+// from the first post of a thread. Fields that are missing are left empty.
+func ParseThreadDetails(doc *goquery.Document) ThreadDetails {
+	var details ThreadDetails
+
+	post := doc.Find("article.message-threadStarterPost .bbWrapper").First()
+	if post.Length() == 0 {
+		post = doc.Find(".message .bbWrapper").First()
+	}
+	if post.Length() == 0 {
+		return details
+	}
+
+	// Spoilers come after the header, and their content must not be mistaken
+	// for header fields
+	header := post.Clone()
+	header.Find(".bbCodeSpoiler").Remove()
+
+	for _, line := range strings.Split(blockText(header), "\n") {
+		matches := headerFieldPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if len(matches) < 3 {
+			continue
+		}
+		value := strings.TrimSpace(matches[2])
+
+		switch matches[1] {
+		case "Thread Updated":
+			if details.ThreadUpdated.IsZero() {
+				details.ThreadUpdated = parseThreadDate(value)
+			}
+		case "Release Date":
+			if details.ReleaseDate.IsZero() {
+				details.ReleaseDate = parseThreadDate(value)
+			}
+		case "Developer", "Publisher":
+			// Developers usually follow their name with " - Patreon - Itch" links
+			if details.Developer == "" {
+				details.Developer = strings.TrimSpace(strings.Split(value, " - ")[0])
+			}
+		case "Censored":
+			if details.Censored == "" {
+				details.Censored = value
+			}
+		case "OS":
+			if details.OS == nil {
+				details.OS = splitList(value)
+			}
+		case "Language":
+			if details.Languages == nil {
+				details.Languages = splitList(value)
+			}
+		}
+	}
+
+	details.Changelog = changelogSpoiler(post)
+
+	return details
+}
+
+// changelogSpoiler returns the text of the first spoiler titled "Changelog"
+func changelogSpoiler(post *goquery.Selection) string {
+	var changelog string
+
+	post.Find(".bbCodeSpoiler").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		title := strings.ToLower(s.Find(".bbCodeSpoiler-button-title").First().Text())
+		if !strings.Contains(title, "changelog") && !strings.Contains(title, "change log") {
+			return true
+		}
+
+		content := s.Find(".bbCodeBlock-content").First()
+		if content.Length() == 0 {
+			content = s.Find(".bbCodeSpoiler-content").First()
+		}
+		changelog = blockText(content)
+		return false
+	})
+
+	return changelog
+}
+
+// blockText returns the text of a selection, keeping line breaks from <br>
+// and block elements that Selection.Text would drop
+func blockText(s *goquery.Selection) string {
+	var sb strings.Builder
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			sb.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			switch n.Data {
+			case "br":
+				sb.WriteString("\n")
+				return
+			case "script", "style":
+				return
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "div", "p", "li", "ul", "ol", "h1", "h2", "h3", "h4", "blockquote":
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	for _, n := range s.Nodes {
+		walk(n)
+	}
+
+	// Collapse the blank lines and stray spaces left behind by the markup
+	var lines []string
+	for _, line := range strings.Split(sb.String(), "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// parseThreadDate tries the date formats commonly used in thread headers
+func parseThreadDate(value string) time.Time {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// splitList splits comma separated header values such as "Windows, Linux, Mac"
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
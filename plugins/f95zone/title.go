@@ -0,0 +1,150 @@
+package f95zone
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Thread statuses shown as title prefixes. Threads without one are still in
+// development.
+const (
+	StatusOngoing   = "Ongoing"
+	StatusCompleted = "Completed"
+	StatusAbandoned = "Abandoned"
+	StatusOnHold    = "Onhold"
+)
+
+// ThreadTitle is a game thread title split into its parts
+type ThreadTitle struct {
+	Name      string // Game name without prefixes and bracketed suffixes
+	Version   string // e.g. "0.7.1" or "Ch.3 Ep.2"
+	Developer string
+	Engine    string // e.g. "Ren'Py", "RPGM", "Unity"
+	Status    string // StatusCompleted, StatusAbandoned, StatusOnHold or empty
+}
+
+// engines maps lower-case prefixes to their canonical engine names
+var engines = map[string]string{
+	"ren'py":        "Ren'Py",
+	"renpy":         "Ren'Py",
+	"rpgm":          "RPGM",
+	"unity":         "Unity",
+	"unreal engine": "Unreal Engine",
+	"ue4":           "Unreal Engine",
+	"ue5":           "Unreal Engine",
+	"html":          "HTML",
+	"flash":         "Flash",
+	"java":          "Java",
+	"qsp":           "QSP",
+	"rags":          "RAGS",
+	"tads":          "Tads",
+	"adrift":        "ADRIFT",
+	"webgl":         "WebGL",
+	"wolf rpg":      "Wolf RPG",
+	"godot":         "Godot",
+	"others":        "Others",
+}
+
+// statuses maps lower-case prefixes to their canonical status names
+var statuses = map[string]string{
+	"completed": StatusCompleted,
+	"abandoned": StatusAbandoned,
+	"onhold":    StatusOnHold,
+	"on hold":   StatusOnHold,
+}
+
+// otherPrefixes are category prefixes that carry no information we keep
+var otherPrefixes = map[string]bool{
+	"vn":         true,
+	"collection": true,
+	"mod":        true,
+	"cheat mod":  true,
+	"siterip":    true,
+	"tool":       true,
+	"tutorial":   true,
+}
+
+var (
+	bracketPrefixPattern = regexp.MustCompile(`^\[([^\]]*)\]\s*`)
+	dashPrefixPattern    = regexp.MustCompile(`^([^\[\]-]+?)\s+-\s+`)
+	bracketSuffixPattern = regexp.MustCompile(`\s*\[([^\]]*)\]\s*$`)
+	titleVersionPattern  = regexp.MustCompile(`(?i)^(v\s?\d|\d|ch(apter)?\.?\s?\d|ep(isode)?\.?\s?\d|season\s?\d|s\d|final|build\s?\d|alpha|beta|demo)`)
+)
+
+// ParseTitle splits a thread title such as
+// "[Ren'Py] [Completed] Game Name [v0.7.1] [Developer]" or
+// "Ren'Py - Completed - Game Name [v0.7.1] [Developer]" into its parts.
+// Parts that are not present are left empty.
+func ParseTitle(title string) ThreadTitle {
+	var parsed ThreadTitle
+	rest := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(title), "| F95zone"))
+
+	// Leading engine/status prefixes. Any bracketed prefix is dropped, but
+	// "X - " prefixes only when known, as game names may contain dashes.
+	for {
+		if m := bracketPrefixPattern.FindStringSubmatch(rest); m != nil {
+			parsed.applyPrefix(m[1])
+			rest = rest[len(m[0]):]
+			continue
+		}
+		if m := dashPrefixPattern.FindStringSubmatch(rest); m != nil && parsed.applyPrefix(m[1]) {
+			rest = rest[len(m[0]):]
+			continue
+		}
+		break
+	}
+
+	// Trailing bracket groups: version first, developer last
+	var suffixes []string
+	for {
+		m := bracketSuffixPattern.FindStringSubmatch(rest)
+		if m == nil {
+			break
+		}
+		suffixes = append([]string{strings.TrimSpace(m[1])}, suffixes...)
+		rest = rest[:len(rest)-len(m[0])]
+	}
+
+	for _, suffix := range suffixes {
+		if parsed.Version == "" && titleVersionPattern.MatchString(suffix) {
+			parsed.Version = cleanTitleVersion(suffix)
+			continue
+		}
+		if suffix != "" {
+			parsed.Developer = suffix
+		}
+	}
+
+	parsed.Name = strings.TrimSpace(rest)
+	return parsed
+}
+
+// applyPrefix records a known engine or status prefix and reports whether
+// the prefix was recognised
+func (t *ThreadTitle) applyPrefix(prefix string) bool {
+	key := strings.ToLower(strings.TrimSpace(prefix))
+	if engine, ok := engines[key]; ok {
+		if t.Engine == "" {
+			t.Engine = engine
+		}
+		return true
+	}
+	if status, ok := statuses[key]; ok {
+		t.Status = status
+		return true
+	}
+	return otherPrefixes[key]
+}
+
+// EngineFromCategory maps an RSS category to an engine name, if it is one
+func EngineFromCategory(category string) string {
+	return engines[strings.ToLower(strings.TrimSpace(category))]
+}
+
+// cleanTitleVersion drops the "v" in front of numeric versions like "v0.7.1"
+func cleanTitleVersion(v string) string {
+	if len(v) > 1 && (v[0] == 'v' || v[0] == 'V') && v[1] >= '0' && v[1] <= '9' {
+		return v[1:]
+	}
+	return v
+}
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gamelauncher/models"
+	"gamelauncher/monitor"
+	"gamelauncher/version"
+)
+
+// DefaultAPIBaseURL is the public GitHub REST API endpoint.
+const DefaultAPIBaseURL = "https://api.github.com"
+
+// Release mirrors the fields of the GitHub releases API we care about
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// UpdateProvider implements monitor.Provider for github.com repositories
+type UpdateProvider struct {
+	apiBaseURL string
+}
+
+var _ monitor.Provider = (*UpdateProvider)(nil)
+
+// NewProvider creates a GitHub provider talking to the given API base URL.
+// An empty base URL selects DefaultAPIBaseURL.
+func NewProvider(apiBaseURL string) *UpdateProvider {
+	if apiBaseURL == "" {
+		apiBaseURL = DefaultAPIBaseURL
+	}
+	return &UpdateProvider{apiBaseURL: strings.TrimSuffix(apiBaseURL, "/")}
+}
+
+func (p *UpdateProvider) Name() string { return "github" }
+
+func (p *UpdateProvider) Match(sourceURL string) bool {
+	_, _, ok := ParseRepoURL(sourceURL)
+	return ok
+}
+
+func init() { monitor.RegisterProvider(NewProvider(DefaultAPIBaseURL)) }
+
+// Check queries the releases API and reports the latest published release
+func (p *UpdateProvider) Check(ctx context.Context, client *http.Client, game *models.Game) (*monitor.UpdateInfo, error) {
+	owner, repo, ok := ParseRepoURL(game.SourceURL)
+	if !ok {
+		return nil, fmt.Errorf("not a GitHub repository URL: %s", game.SourceURL)
+	}
+
+	releases, err := p.fetchReleases(ctx, client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	release := LatestRelease(releases, game.IncludePrereleases)
+	if release == nil {
+		return nil, monitor.NewParseError(game.SourceURL, fmt.Errorf("no published releases found for %s/%s", owner, repo))
+	}
+
+	latest := release.TagName
+
+	// On the first check adopt the latest release as the current version
+	if game.CurrentVersion == "" {
+		game.CurrentVersion = latest
+	}
+
+	releaseURL := release.HTMLURL
+	if releaseURL == "" {
+		releaseURL = game.SourceURL
+	}
+
+	return &monitor.UpdateInfo{
+		HasUpdate:   version.IsNewer(latest, game.CurrentVersion),
+		Version:     latest,
+		URL:         releaseURL,
+		ReleaseDate: release.PublishedAt,
+		Description: release.Body,
+		Changelog:   release.Body,
+	}, nil
+}
+
+// fetchReleases downloads the first page of releases for a repository
+func (p *UpdateProvider) fetchReleases(ctx context.Context, client *http.Client, owner, repo string) ([]Release, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/releases", p.apiBaseURL, url.PathEscape(owner), url.PathEscape(repo))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := monitor.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, monitor.NewParseError(apiURL, fmt.Errorf("failed to decode releases: %w", err))
+	}
+	return releases, nil
+}
+
+// LatestRelease picks the most recently published non-draft release.
+// Prereleases are skipped unless includePrereleases is set.
+func LatestRelease(releases []Release, includePrereleases bool) *Release {
+	var latest *Release
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || (r.Prerelease && !includePrereleases) {
+			continue
+		}
+		if latest == nil || r.PublishedAt.After(latest.PublishedAt) {
+			latest = r
+		}
+	}
+	return latest
+}
+
+// ParseRepoURL extracts owner and repository from a github.com URL such as
+// https://github.com/owner/repo or https://github.com/owner/repo/releases.
+// The scheme may be left out, as in github.com/owner/repo.
+func ParseRepoURL(sourceURL string) (owner, repo string, ok bool) {
+	raw := strings.TrimSpace(sourceURL)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != "github.com" && host != "www.github.com" {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
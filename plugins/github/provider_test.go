@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gamelauncher/models"
+	"gamelauncher/monitor"
+)
+
+const releasesJSON = `[
+	{"tag_name": "v1.3.0-beta", "name": "Beta", "body": "Beta notes", "html_url": "https://github.com/owner/repo/releases/tag/v1.3.0-beta", "prerelease": true, "published_at": "2024-03-01T00:00:00Z"},
+	{"tag_name": "v1.4.0", "name": "Draft", "draft": true, "published_at": "2024-04-01T00:00:00Z"},
+	{"tag_name": "v1.2.0", "name": "Version 1.2", "body": "Stable notes", "html_url": "https://github.com/owner/repo/releases/tag/v1.2.0", "published_at": "2024-02-01T00:00:00Z"},
+	{"tag_name": "v1.1.0", "name": "Version 1.1", "body": "Old notes", "published_at": "2024-01-01T00:00:00Z"}
+]`
+
+// newTestProvider serves handler as the GitHub API and returns a provider
+// talking to it
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *UpdateProvider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewProvider(server.URL)
+}
+
+func serveReleases(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/releases" {
+			t.Errorf("unexpected request path %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, releasesJSON)
+	}
+}
+
+func TestCheckLatestRelease(t *testing.T) {
+	provider := newTestProvider(t, serveReleases(t))
+	game := &models.Game{SourceURL: "https://github.com/owner/repo", CurrentVersion: "v1.1.0"}
+
+	info, err := provider.Check(context.Background(), http.DefaultClient, game)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if info.Version != "v1.2.0" {
+		t.Errorf("Version = %q, want the tag v1.2.0", info.Version)
+	}
+	if !info.HasUpdate {
+		t.Error("HasUpdate = false, want true")
+	}
+	if info.Description != "Stable notes" || info.Changelog != "Stable notes" {
+		t.Errorf("Description = %q, Changelog = %q, want the release notes", info.Description, info.Changelog)
+	}
+	if info.URL != "https://github.com/owner/repo/releases/tag/v1.2.0" {
+		t.Errorf("URL = %q, want the release page", info.URL)
+	}
+	if want := "2024-02-01"; info.ReleaseDate.Format("2006-01-02") != want {
+		t.Errorf("ReleaseDate = %v, want %s", info.ReleaseDate, want)
+	}
+}
+
+func TestCheckPrereleases(t *testing.T) {
+	provider := newTestProvider(t, serveReleases(t))
+	game := &models.Game{SourceURL: "https://github.com/owner/repo", CurrentVersion: "v1.2.0", IncludePrereleases: true}
+
+	info, err := provider.Check(context.Background(), http.DefaultClient, game)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if info.Version != "v1.3.0-beta" {
+		t.Errorf("Version = %q, want the prerelease v1.3.0-beta", info.Version)
+	}
+	if !info.HasUpdate {
+		t.Error("HasUpdate = false, want true")
+	}
+}
+
+func TestCheckFirstCheckAdoptsLatest(t *testing.T) {
+	provider := newTestProvider(t, serveReleases(t))
+	game := &models.Game{SourceURL: "github.com/owner/repo"}
+
+	info, err := provider.Check(context.Background(), http.DefaultClient, game)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if game.CurrentVersion != "v1.2.0" {
+		t.Errorf("CurrentVersion = %q, want v1.2.0", game.CurrentVersion)
+	}
+	if info.HasUpdate {
+		t.Error("HasUpdate = true on the first check")
+	}
+}
+
+func TestCheckErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		kind    monitor.ErrorKind
+	}{
+		{
+			name:    "not found",
+			handler: http.NotFound,
+			kind:    monitor.ErrorNotFound,
+		},
+		{
+			name: "too many requests",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			kind: monitor.ErrorRateLimited,
+		},
+		{
+			name: "quota exhausted",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", "4102444800")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			kind: monitor.ErrorRateLimited,
+		},
+		{
+			name: "no releases",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `[{"tag_name": "v2.0", "draft": true}]`)
+			},
+			kind: monitor.ErrorParse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestProvider(t, tt.handler)
+			game := &models.Game{SourceURL: "https://github.com/owner/repo"}
+
+			_, err := provider.Check(context.Background(), http.DefaultClient, game)
+			var srcErr *monitor.SourceError
+			if !errors.As(err, &srcErr) {
+				t.Fatalf("Check error = %v, want a *monitor.SourceError", err)
+			}
+			if srcErr.Kind != tt.kind {
+				t.Errorf("Kind = %s, want %s", srcErr.Kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestParseRepoURL(t *testing.T) {
+	tests := []struct {
+		url         string
+		owner, repo string
+		ok          bool
+	}{
+		{"https://github.com/owner/repo", "owner", "repo", true},
+		{"https://www.github.com/owner/repo/releases", "owner", "repo", true},
+		{"http://github.com/owner/repo.git", "owner", "repo", true},
+		{"github.com/owner/repo", "owner", "repo", true},
+		{"  github.com/owner/repo/releases/latest ", "owner", "repo", true},
+		{"https://github.com/owner", "", "", false},
+		{"https://gitlab.com/owner/repo", "", "", false},
+		{"https://f95zone.to/threads/game.123/", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, tt := range tests {
+		owner, repo, ok := ParseRepoURL(tt.url)
+		if owner != tt.owner || repo != tt.repo || ok != tt.ok {
+			t.Errorf("ParseRepoURL(%q) = %q, %q, %v, want %q, %q, %v",
+				tt.url, owner, repo, ok, tt.owner, tt.repo, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package saves
+
+import (
+	"bufio"
+	"gamelauncher/models"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// Engine names as reported by game.DetectEngine
+const (
+	engineRenPy = "Ren'Py"
+	engineRPGM  = "RPGM"
+	engineUnity = "Unity"
+)
+
+// gameFolders are the folders inside a game holding its saves, by engine.
+// Games of every engine are also checked for the generic names under "".
+var gameFolders = map[string][]string{
+	engineRenPy: {filepath.Join("game", "saves")},
+	engineRPGM:  {filepath.Join("www", "save"), "save"},
+	"":          {"saves", "save", "Saves", "Save"},
+}
+
+// saveDirectoryPattern matches `define config.save_directory = "Game-1234"`
+var saveDirectoryPattern = regexp.MustCompile(`^\s*(?:define\s+)?config\.save_directory\s*=\s*[uU]?["']([^"']+)["']`)
+
+// GameFolders returns the save folders inside a game folder for an engine,
+// relative to the game folder
+func GameFolders(engine string) []string {
+	folders := append([]string(nil), gameFolders[engine]...)
+	if engine != "" {
+		folders = append(folders, gameFolders[""]...)
+	}
+	return folders
+}
+
+// Locations returns the existing folders holding the saves of game: the
+// folders configured for the game, or the ones detected for its engine
+func Locations(game *models.Game) []string {
+	candidates := game.SaveDirs
+	if len(candidates) == 0 {
+		candidates = DetectLocations(game)
+	}
+
+	// "saves" and "Saves" are the same folder on Windows and macOS
+	var (
+		existing []string
+		infos    []os.FileInfo
+	)
+	for _, dir := range candidates {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		duplicate := false
+		for _, seen := range infos {
+			if os.SameFile(seen, info) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			existing = append(existing, filepath.Clean(dir))
+			infos = append(infos, info)
+		}
+	}
+	return existing
+}
+
+// DetectLocations returns where the engine of game keeps saves, whether or
+// not the folders exist yet. Ren'Py and Unity keep saves in the user's
+// profile as well as, for Ren'Py, inside the game.
+func DetectLocations(game *models.Game) []string {
+	folder := game.Folder
+	if folder == "" {
+		folder = filepath.Dir(game.Executable)
+	}
+
+	var locations []string
+	for _, rel := range GameFolders(game.Engine) {
+		locations = append(locations, filepath.Join(folder, rel))
+	}
+
+	switch game.Engine {
+	case engineRenPy:
+		if dir := renpySaveDirectory(folder); dir != "" {
+			locations = append(locations, filepath.Join(renpyUserDir(), dir))
+		} else {
+			// Archived scripts can't be read; Ren'Py names the folder after
+			// the game followed by a number, e.g. "MyGame-1234567890"
+			locations = append(locations, renpyNumberedDirs(strings.ReplaceAll(game.Name, " ", ""))...)
+		}
+	case engineUnity:
+		if dir := unitySaveDirectory(folder); dir != "" {
+			locations = append(locations, dir)
+		}
+	}
+	return dedupe(locations)
+}
+
+// renpySaveDirectory reads config.save_directory from the game's scripts
+func renpySaveDirectory(folder string) string {
+	scripts, _ := filepath.Glob(filepath.Join(folder, "game", "*.rpy"))
+	for _, script := range scripts {
+		if dir := firstMatch(script, saveDirectoryPattern); dir != "" {
+			return dir
+		}
+	}
+	return ""
+}
+
+// renpyNumberedDirs returns the folders in Ren'Py's user folder named
+// exactly name followed by a dash and digits, so "Game" doesn't match the
+// saves of "Game-Of-Thrones-123"
+func renpyNumberedDirs(name string) []string {
+	if name == "" {
+		return nil
+	}
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(name) + `-[0-9]+$`)
+	entries, err := os.ReadDir(renpyUserDir())
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() && pattern.MatchString(entry.Name()) {
+			dirs = append(dirs, filepath.Join(renpyUserDir(), entry.Name()))
+		}
+	}
+	return dirs
+}
+
+// renpyUserDir returns the folder Ren'Py keeps per-game save folders in
+func renpyUserDir() string {
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "windows":
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "RenPy")
+		}
+		return filepath.Join(home, "AppData", "Roaming", "RenPy")
+	case "darwin":
+		return filepath.Join(home, "Library", "RenPy")
+	default:
+		return filepath.Join(home, ".renpy")
+	}
+}
+
+// unitySaveDirectory returns Unity's persistent data folder for the game,
+// named after the company and product in <Name>_Data/app.info
+func unitySaveDirectory(folder string) string {
+	matches, _ := filepath.Glob(filepath.Join(folder, "*_Data", "app.info"))
+	if len(matches) == 0 {
+		return ""
+	}
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) < 2 || strings.TrimSpace(lines[0]) == "" || strings.TrimSpace(lines[1]) == "" {
+		return ""
+	}
+	company, product := strings.TrimSpace(lines[0]), strings.TrimSpace(lines[1])
+
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(home, "AppData", "LocalLow", company, product)
+	case "darwin":
+		return filepath.Join(home, "Library", "Application Support", company, product)
+	default:
+		return filepath.Join(home, ".config", "unity3d", company, product)
+	}
+}
+
+// firstMatch returns the first submatch of pattern in the file at path
+func firstMatch(path string, pattern *regexp.Regexp) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if m := pattern.FindStringSubmatch(scanner.Text()); m != nil {
+			return strings.TrimSpace(m[1])
+		}
+	}
+	return ""
+}
+
+func dedupe(paths []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, p := range paths {
+		key := filepath.Clean(p)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,394 @@
+package saves
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gamelauncher/models"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// snapshotDir holds the save snapshots inside the data directory, one
+	// folder per game ID
+	snapshotDir = "saves"
+
+	// DefaultKeep is the number of snapshots kept per game when the settings
+	// don't say otherwise
+	DefaultKeep = 10
+
+	manifestName       = "manifest.json"
+	snapshotTimeLayout = "20060102-150405.000000"
+)
+
+// Reasons a snapshot was taken
+const (
+	ReasonLaunch    = "launch"
+	ReasonUpdate    = "update"
+	ReasonManual    = "manual"
+	ReasonRestore   = "restore"   // Taken before restoring another snapshot
+	ReasonUninstall = "uninstall" // Taken before the game folder is removed
+)
+
+// Snapshot is a zip archive of a game's save folders at one point in time
+type Snapshot struct {
+	Path      string    `json:"-"`
+	GameID    string    `json:"game_id"`
+	Created   time.Time `json:"created"`
+	Reason    string    `json:"reason"`
+	Locations []string  `json:"locations"` // Save folders, stored as 0/, 1/, ... in the archive
+	Files     int       `json:"files"`     // Number of save files
+	Size      int64     `json:"size"`      // Total size of the save files
+	Checksum  string    `json:"checksum"`  // Of the saves' names, sizes and times, to skip unchanged saves
+}
+
+// Manager takes, lists and restores save snapshots kept in the data directory
+type Manager struct {
+	mu   sync.Mutex
+	dir  string
+	keep int
+}
+
+// NewManager creates a snapshot manager keeping snapshots below dataDir
+func NewManager(dataDir string) *Manager {
+	return &Manager{dir: filepath.Join(dataDir, snapshotDir), keep: DefaultKeep}
+}
+
+// SetKeep sets how many snapshots are kept per game. Zero disables automatic
+// snapshots; negative values select DefaultKeep.
+func (m *Manager) SetKeep(keep int) {
+	if keep < 0 {
+		keep = DefaultKeep
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keep = keep
+}
+
+// AutoSnapshot takes a snapshot before the game is launched or updated,
+// unless automatic snapshots are disabled, the game has no saves or they
+// did not change since the last snapshot. It returns nil in those cases.
+func (m *Manager) AutoSnapshot(game *models.Game, reason string) (*Snapshot, error) {
+	m.mu.Lock()
+	keep := m.keep
+	m.mu.Unlock()
+	if keep == 0 {
+		return nil, nil
+	}
+	return m.snapshot(game, reason, true)
+}
+
+// Snapshot takes a snapshot of the game's saves now. It returns nil if the
+// game has no save folders.
+func (m *Manager) Snapshot(game *models.Game, reason string) (*Snapshot, error) {
+	return m.snapshot(game, reason, false)
+}
+
+func (m *Manager) snapshot(game *models.Game, reason string, skipUnchanged bool) (*Snapshot, error) {
+	locations := Locations(game)
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snap := &Snapshot{GameID: game.ID, Created: time.Now(), Reason: reason, Locations: locations}
+	files, err := collectFiles(locations, snap)
+	if err != nil {
+		return nil, err
+	}
+	if snap.Files == 0 {
+		return nil, nil
+	}
+
+	existing, err := m.listLocked(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	if skipUnchanged && len(existing) > 0 && existing[0].Checksum == snap.Checksum {
+		return nil, nil
+	}
+
+	dir := filepath.Join(m.dir, game.ID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	snap.Path = filepath.Join(dir, fmt.Sprintf("%s-%s.zip", snap.Created.Format(snapshotTimeLayout), reason))
+	if err := writeSnapshot(snap, files); err != nil {
+		os.Remove(snap.Path)
+		return nil, fmt.Errorf("failed to back up saves of %s: %w", game.Name, err)
+	}
+
+	// A snapshot taken before a restore must not prune the one restored
+	if reason != ReasonRestore {
+		if err := m.pruneLocked(game.ID); err != nil {
+			return snap, err
+		}
+	}
+	return snap, nil
+}
+
+// List returns the snapshots of a game, newest first
+func (m *Manager) List(gameID string) ([]Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.listLocked(gameID)
+}
+
+func (m *Manager) listLocked(gameID string) ([]Snapshot, error) {
+	matches, err := filepath.Glob(filepath.Join(m.dir, gameID, "*.zip"))
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, match := range matches {
+		snap, err := readManifest(match)
+		if err != nil {
+			// Unreadable archives are left alone rather than hiding the rest
+			continue
+		}
+		snapshots = append(snapshots, *snap)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Created.After(snapshots[j].Created)
+	})
+	return snapshots, nil
+}
+
+// Restore writes the saves in snap back to their folders, replacing files
+// of the same name. Saves made since are left in place. The current saves
+// are snapshotted first so the restore can be undone.
+func (m *Manager) Restore(game *models.Game, snap Snapshot) error {
+	if _, err := m.Snapshot(game, ReasonRestore); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	zr, err := zip.OpenReader(snap.Path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name == manifestName || f.FileInfo().IsDir() {
+			continue
+		}
+		index, rel, ok := strings.Cut(f.Name, "/")
+		i, err := strconv.Atoi(index)
+		if !ok || err != nil || i < 0 || i >= len(snap.Locations) {
+			continue
+		}
+		target := filepath.Join(snap.Locations[i], filepath.FromSlash(rel))
+		if !strings.HasPrefix(target, filepath.Clean(snap.Locations[i])+string(filepath.Separator)) {
+			return fmt.Errorf("snapshot entry %q points outside the save folder", f.Name)
+		}
+		if err := extractFile(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes a snapshot
+func (m *Manager) Delete(snap Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return os.Remove(snap.Path)
+}
+
+// DeleteAll removes every snapshot of a game
+func (m *Manager) DeleteAll(gameID string) error {
+	if gameID == "" {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return os.RemoveAll(filepath.Join(m.dir, gameID))
+}
+
+// pruneLocked deletes the oldest snapshots of a game beyond the keep limit.
+// With automatic snapshots disabled, manual ones are kept.
+func (m *Manager) pruneLocked(gameID string) error {
+	if m.keep == 0 {
+		return nil
+	}
+	snapshots, err := m.listLocked(gameID)
+	if err != nil || len(snapshots) <= m.keep {
+		return err
+	}
+
+	var errs []error
+	for _, old := range snapshots[m.keep:] {
+		if err := os.Remove(old.Path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// saveFile is a file to store in a snapshot
+type saveFile struct {
+	path string
+	name string // Name inside the archive
+	info os.FileInfo
+}
+
+// collectFiles lists the files in the save folders and fills in the
+// snapshot's file count, size and checksum
+func collectFiles(locations []string, snap *Snapshot) ([]saveFile, error) {
+	var files []saveFile
+	hash := sha256.New()
+	for i, location := range locations {
+		err := filepath.Walk(location, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(location, p)
+			if err != nil {
+				return err
+			}
+			name := path.Join(strconv.Itoa(i), filepath.ToSlash(rel))
+			files = append(files, saveFile{path: p, name: name, info: info})
+			fmt.Fprintf(hash, "%s\x00%d\x00%d\n", name, info.Size(), info.ModTime().UnixNano())
+			snap.Files++
+			snap.Size += info.Size()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	snap.Checksum = hex.EncodeToString(hash.Sum(nil))
+	return files, nil
+}
+
+// writeSnapshot writes the save files and the manifest into snap.Path
+func writeSnapshot(snap *Snapshot, files []saveFile) error {
+	out, err := os.Create(snap.Path)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(out)
+
+	for _, f := range files {
+		header, err := zip.FileInfoHeader(f.info)
+		if err != nil {
+			zw.Close()
+			out.Close()
+			return err
+		}
+		header.Name = f.name
+		header.Method = zip.Deflate
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			zw.Close()
+			out.Close()
+			return err
+		}
+		if err := copyFile(w, f.path); err != nil {
+			zw.Close()
+			out.Close()
+			return err
+		}
+	}
+
+	manifest, err := json.MarshalIndent(snap, "", "  ")
+	if err == nil {
+		var w io.Writer
+		if w, err = zw.Create(manifestName); err == nil {
+			_, err = w.Write(manifest)
+		}
+	}
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// readManifest reads the description stored inside a snapshot archive
+func readManifest(archive string) (*Snapshot, error) {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != manifestName {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		var snap Snapshot
+		if err := json.NewDecoder(rc).Decode(&snap); err != nil {
+			return nil, err
+		}
+		snap.Path = archive
+		return &snap, nil
+	}
+	return nil, fmt.Errorf("%s has no manifest", filepath.Base(archive))
+}
+
+func copyFile(w io.Writer, path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	_, err = io.Copy(w, in)
+	return err
+}
+
+func extractFile(f *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	// Keep the save times, games sort their save slots by them
+	if f.Modified.IsZero() {
+		return nil
+	}
+	return os.Chtimes(target, f.Modified, f.Modified)
+}
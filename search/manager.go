@@ -18,6 +18,12 @@ type Plugin interface {
 	DownloadImageForResult(result *SearchResult) error
 }
 
+// DataDirAware is implemented by plugins that keep files (such as downloaded
+// images) in the launcher's data directory.
+type DataDirAware interface {
+	SetDataDir(dataDir string)
+}
+
 // global registry that plugins populate from their init() functions.
 var registeredPlugins []Plugin
 
@@ -33,8 +39,14 @@ type Manager struct {
 	plugins []Plugin
 }
 
-// NewManager constructs a manager using the registered plugin list.
-func NewManager() *Manager {
+// NewManager constructs a manager using the registered plugin list. Plugins
+// implementing DataDirAware are pointed at dataDir.
+func NewManager(dataDir string) *Manager {
+	for _, p := range registeredPlugins {
+		if aware, ok := p.(DataDirAware); ok {
+			aware.SetDataDir(dataDir)
+		}
+	}
 	return &Manager{plugins: registeredPlugins}
 }
 
@@ -10,6 +10,13 @@ type SearchResult struct {
 	MatchScore  float64 // How well the game name matches
 	ImageURL    string  // URL of the image from description or scraped page
 	ImagePath   string  // Local path where image is stored (after download)
+
+	// Parts of Title, filled in by plugins whose sites use structured titles
+	Name      string // Game name without version, developer or tags
+	Version   string
+	Developer string
+	Engine    string
+	Status    string // e.g. "Completed" or "Abandoned"; empty while in development
 }
 
 // ImageCandidate is an intermediate structure used by plugins while scraping
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	gamepkg "gamelauncher/game"
 	"gamelauncher/models"
 	"hash/crc32"
 	"io"
@@ -104,6 +105,55 @@ func (m *Manager) CheckGameExistsInSteam(game *models.Game) (bool, error) {
 	return m.checkGameExistsInSteam(shortcutsPath, game)
 }
 
+// RemoveGameFromSteam removes the game's non-Steam shortcut. It reports
+// whether a shortcut was found; Steam must be restarted to notice.
+func (m *Manager) RemoveGameFromSteam(game *models.Game) (bool, error) {
+	// Find Steam installation
+	steamPath, err := m.findSteamPath()
+	if err != nil {
+		return false, fmt.Errorf("failed to find Steam installation: %w", err)
+	}
+
+	// Find user data directory
+	userDataPath, err := m.findUserDataPath(steamPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to find Steam user data: %w", err)
+	}
+
+	shortcutsPath := filepath.Join(userDataPath, "config", "shortcuts.vdf")
+	return m.removeShortcutFromFile(shortcutsPath, game)
+}
+
+// removeShortcutFromFile drops the game's shortcuts from the shortcuts.vdf
+// file, matching them like checkGameExistsInSteam
+func (m *Manager) removeShortcutFromFile(shortcutsPath string, game *models.Game) (bool, error) {
+	shortcuts, err := m.readShortcutsFile(shortcutsPath)
+	if err != nil {
+		// No shortcuts file, nothing to remove
+		return false, nil
+	}
+
+	appID := m.generateAppID(game.Name, game.Executable)
+	normalizedName := m.normalizeName(game.Name)
+
+	var kept []*SteamShortcut
+	for _, existing := range shortcuts {
+		if existing.AppID == appID || m.normalizeName(existing.AppName) == normalizedName {
+			log.Printf("Removing Steam shortcut: %s (AppID: %d)", existing.AppName, existing.AppID)
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	if len(kept) == len(shortcuts) {
+		return false, nil
+	}
+
+	if err := m.writeShortcutsFile(shortcutsPath, kept); err != nil {
+		return false, fmt.Errorf("failed to remove shortcut from Steam: %w", err)
+	}
+	return true, nil
+}
+
 // checkGameExistsInSteam internal function to check if game exists in shortcuts file
 func (m *Manager) checkGameExistsInSteam(shortcutsPath string, game *models.Game) (bool, error) {
 	// Read existing shortcuts
@@ -256,9 +306,13 @@ func (m *Manager) createShortcutFromGame(game *models.Game) *SteamShortcut {
 	// Generate AppID
 	appID := m.generateAppID(game.Name, game.Executable)
 
-	// Always use the executable's parent directory as StartDir
+	// Use the executable's parent directory as StartDir unless the game
+	// overrides its working directory
 	// This ensures Steam starts the game from the correct directory
 	startDir := filepath.Dir(game.Executable)
+	if game.WorkingDir != "" {
+		startDir = game.WorkingDir
+	}
 
 	// Format executable path and start directory according to platform requirements
 	exe, startDir := m.formatPathsForPlatform(game.Executable, startDir)
@@ -276,7 +330,7 @@ func (m *Manager) createShortcutFromGame(game *models.Game) *SteamShortcut {
 		StartDir:            startDir, // Use executable's parent directory
 		Icon:                icon,
 		ShortcutPath:        "",
-		LaunchOptions:       "",
+		LaunchOptions:       m.launchOptions(game),
 		IsHidden:            false,
 		AllowDesktopConfig:  true,
 		AllowOverlay:        true,
@@ -292,6 +346,33 @@ func (m *Manager) createShortcutFromGame(game *models.Game) *SteamShortcut {
 	}
 }
 
+// launchOptions builds Steam launch options from the game's arguments and
+// environment. Steam only supports environment variables through %command%
+// on Linux and macOS, so they are left out on Windows.
+func (m *Manager) launchOptions(game *models.Game) string {
+	var parts []string
+	if len(game.LaunchEnv) > 0 && runtime.GOOS != "windows" {
+		for _, entry := range game.LaunchEnv {
+			key, value, _ := strings.Cut(entry, "=")
+			parts = append(parts, key+"="+gamepkg.QuoteArg(value))
+		}
+		parts = append(parts, "%command%")
+	}
+	if len(game.LaunchArgs) > 0 {
+		parts = append(parts, gamepkg.JoinArgs(game.LaunchArgs))
+	}
+	return strings.Join(parts, " ")
+}
+
+// mergeLaunchOptions keeps launch options set in Steam unless the game has
+// its own
+func (m *Manager) mergeLaunchOptions(shortcut, existing *SteamShortcut) string {
+	if shortcut.LaunchOptions != "" {
+		return shortcut.LaunchOptions
+	}
+	return existing.LaunchOptions
+}
+
 // generateAppID generates a unique AppID for the shortcut based on name only
 func (m *Manager) generateAppID(appName, exe string) uint32 {
 	// Normalize name to ensure consistency across different executable paths
@@ -371,6 +452,7 @@ func (m *Manager) addShortcutToFile(shortcutsPath string, shortcut *SteamShortcu
 		existingShortcut := shortcuts[existingIndex]
 
 		// Create a new shortcut with updated data but preserve existing values
+		launchOptions := m.mergeLaunchOptions(shortcut, existingShortcut)
 		updatedShortcut := &SteamShortcut{
 			AppID:               shortcut.AppID,                       // Use new AppID format
 			AppName:             shortcut.AppName,                     // Update name (should be same anyway)
@@ -378,7 +460,7 @@ func (m *Manager) addShortcutToFile(shortcutsPath string, shortcut *SteamShortcu
 			StartDir:            shortcut.StartDir,                    // Update start directory
 			Icon:                shortcut.Icon,                        // Update icon
 			ShortcutPath:        existingShortcut.ShortcutPath,        // Preserve existing
-			LaunchOptions:       existingShortcut.LaunchOptions,       // Preserve existing
+			LaunchOptions:       launchOptions,                        // Game's options, else preserve existing
 			IsHidden:            existingShortcut.IsHidden,            // Preserve existing
 			AllowDesktopConfig:  existingShortcut.AllowDesktopConfig,  // Preserve existing
 			AllowOverlay:        existingShortcut.AllowOverlay,        // Preserve existing
@@ -896,7 +978,7 @@ func (m *Manager) AddAllGamesToSteam(games []*models.Game) error {
 				StartDir:            shortcut.StartDir,
 				Icon:                shortcut.Icon,
 				ShortcutPath:        existingShortcut.ShortcutPath,
-				LaunchOptions:       existingShortcut.LaunchOptions,
+				LaunchOptions:       m.mergeLaunchOptions(shortcut, existingShortcut),
 				IsHidden:            existingShortcut.IsHidden,
 				AllowDesktopConfig:  existingShortcut.AllowDesktopConfig,
 				AllowOverlay:        existingShortcut.AllowOverlay,
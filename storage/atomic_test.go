@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "games.json")
+
+	for _, content := range []string{"first", "second"} {
+		if err := writeFileAtomic(path, []byte(content), 0644); err != nil {
+			t.Fatalf("writeFileAtomic: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != content {
+			t.Fatalf("file holds %q (%v), want %q", data, err, content)
+		}
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestWriteFileAtomicFailureLeavesNoTempFile(t *testing.T) {
+	dir := t.TempDir()
+
+	// Renaming over a folder that isn't empty fails after the data was written
+	path := filepath.Join(dir, "games.json")
+	if err := os.MkdirAll(filepath.Join(path, "inside"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFileAtomic(path, []byte("data"), 0644); err == nil {
+		t.Fatal("writeFileAtomic succeeded replacing a folder")
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("temporary file %s was left behind", entry.Name())
+		}
+	}
+}
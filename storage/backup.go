@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gamelauncher/models"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// backupDir holds the rolling backups of games.json inside the data directory
+	backupDir = "backups"
+
+	// DefaultBackupCount is the number of games.json backups kept when the
+	// settings don't say otherwise
+	DefaultBackupCount = 5
+
+	backupPrefix     = "games-"
+	backupTimeLayout = "20060102-150405.000000"
+)
+
+// RecoveryError reports that games.json could not be parsed and the library
+// was restored from a backup. LoadGames returns it together with the restored
+// games, so callers can warn the user and carry on.
+type RecoveryError struct {
+	Path        string // The games file that failed to parse
+	CorruptPath string // Where the damaged file was moved to
+	BackupPath  string // The backup the library was restored from
+	Err         error  // The original parse error
+}
+
+func (e *RecoveryError) Error() string {
+	return fmt.Sprintf("%s could not be read (%v); restored from backup %s, the damaged file was kept as %s",
+		e.Path, e.Err, filepath.Base(e.BackupPath), e.CorruptPath)
+}
+
+func (e *RecoveryError) Unwrap() error { return e.Err }
+
+// SetBackupCount sets how many backups of games.json are kept. Zero disables
+// backups; negative values select DefaultBackupCount.
+func (m *Manager) SetBackupCount(count int) {
+	if count < 0 {
+		count = DefaultBackupCount
+	}
+
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+	m.backupCount = count
+}
+
+// backupGames copies the current games file into the backup directory before
+// it is replaced, then prunes old backups. Unreadable or unchanged files are
+// not backed up. Callers must hold saveMu.
+func (m *Manager) backupGames(filePath string) error {
+	if m.backupCount == 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !json.Valid(data) {
+		return nil
+	}
+
+	dir := filepath.Join(m.dataPath, backupDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	backups, err := m.listBackups()
+	if err != nil {
+		return err
+	}
+	if len(backups) > 0 {
+		if latest, err := os.ReadFile(backups[0]); err == nil && bytes.Equal(latest, data) {
+			return nil
+		}
+	}
+
+	name := backupPrefix + time.Now().Format(backupTimeLayout) + ".json"
+	if err := writeFileAtomic(filepath.Join(dir, name), data, 0644); err != nil {
+		return err
+	}
+
+	return m.pruneBackups()
+}
+
+// pruneBackups removes all but the newest backupCount backups
+func (m *Manager) pruneBackups() error {
+	backups, err := m.listBackups()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range backups[min(m.backupCount, len(backups)):] {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// listBackups returns the paths of all games.json backups, newest first
+func (m *Manager) listBackups() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(m.dataPath, backupDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		backups = append(backups, filepath.Join(m.dataPath, backupDir, name))
+	}
+
+	// The timestamp layout sorts chronologically
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// recoverGames restores the games file from the newest backup that parses.
+// The damaged file is kept next to the original for inspection. Callers must
+// hold the data lock.
+func (m *Manager) recoverGames(filePath string, parseErr error) ([]*models.Game, error) {
+	backups, err := m.listBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backupPath := range backups {
+		data, err := os.ReadFile(backupPath)
+		if err != nil {
+			continue
+		}
+
+		games, _, err := decodeGames(backupPath, data)
+		if err != nil {
+			fmt.Printf("Warning: Skipping unreadable backup %s: %v\n", backupPath, err)
+			continue
+		}
+
+		corruptPath := fmt.Sprintf("%s.corrupt-%s", filePath, time.Now().Format("20060102-150405"))
+		if err := os.Rename(filePath, corruptPath); err != nil {
+			return nil, fmt.Errorf("failed to move damaged games file aside: %w", err)
+		}
+		if err := writeFileAtomic(filePath, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to restore backup %s: %w", backupPath, err)
+		}
+
+		return games, &RecoveryError{
+			Path:        filePath,
+			CorruptPath: corruptPath,
+			BackupPath:  backupPath,
+			Err:         parseErr,
+		}
+	}
+
+	return nil, fmt.Errorf("%s could not be read and no valid backup was found: %w", filePath, parseErr)
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gamelauncher/models"
+)
+
+// saveLibrary saves a library of count games named after their number
+func saveLibrary(t *testing.T, m *Manager, count int) {
+	t.Helper()
+	var games []*models.Game
+	for i := 1; i <= count; i++ {
+		games = append(games, &models.Game{ID: fmt.Sprint(i), Name: fmt.Sprintf("Game %d", i)})
+	}
+	if err := m.SaveGames(games); err != nil {
+		t.Fatalf("SaveGames: %v", err)
+	}
+}
+
+func TestBackupsRotate(t *testing.T) {
+	m := NewManager(t.TempDir())
+	m.SetBackupCount(3)
+
+	for count := 1; count <= 6; count++ {
+		saveLibrary(t, m, count)
+	}
+
+	backups, err := m.listBackups()
+	if err != nil {
+		t.Fatalf("listBackups: %v", err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("%d backups kept, want 3", len(backups))
+	}
+
+	// The newest backup is the library before the last save
+	data, err := os.ReadFile(backups[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	games, _, err := decodeGames(backups[0], data)
+	if err != nil || len(games) != 5 {
+		t.Errorf("newest backup holds %d games (%v), want 5", len(games), err)
+	}
+}
+
+func TestBackupsDisabled(t *testing.T) {
+	m := NewManager(t.TempDir())
+	m.SetBackupCount(0)
+
+	saveLibrary(t, m, 1)
+	saveLibrary(t, m, 2)
+
+	if backups, _ := m.listBackups(); len(backups) != 0 {
+		t.Errorf("%d backups written with backups disabled", len(backups))
+	}
+}
+
+func TestTruncatedGamesFileIsRestored(t *testing.T) {
+	m := NewManager(t.TempDir())
+	saveLibrary(t, m, 1)
+	saveLibrary(t, m, 2)
+	saveLibrary(t, m, 3)
+
+	// A damaged backup newer than the others must be skipped
+	damaged := filepath.Join(m.DataPath(), backupDir, backupPrefix+"99991231-235959.000000.json")
+	if err := os.WriteFile(damaged, []byte(`{"schema": 2, "games": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gamesPath := m.gamesPath()
+	data, err := os.ReadFile(gamesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(gamesPath, data[:len(data)/2], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	games, err := NewManager(m.DataPath()).LoadGames()
+	var recovery *RecoveryError
+	if !errors.As(err, &recovery) {
+		t.Fatalf("LoadGames error = %v, want a *RecoveryError", err)
+	}
+	if len(games) != 2 {
+		t.Errorf("restored %d games, want the 2 of the newest valid backup", len(games))
+	}
+	if recovery.BackupPath == damaged {
+		t.Error("restored from the damaged backup")
+	}
+	if _, err := os.Stat(recovery.CorruptPath); err != nil {
+		t.Errorf("damaged games file was not kept: %v", err)
+	}
+
+	// games.json itself was repaired
+	games, err = NewManager(m.DataPath()).LoadGames()
+	if err != nil || len(games) != 2 {
+		t.Errorf("LoadGames after recovery = %d games, %v; want 2 games", len(games), err)
+	}
+}
@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"gamelauncher/models"
+	"gamelauncher/version"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Library export formats
+const (
+	FormatBundle   = "bundle"   // Zip of games.json, settings.json and images
+	FormatCSV      = "csv"      // One row per game, for spreadsheets
+	FormatMarkdown = "markdown" // Human readable report, export only
+	FormatJSON     = "json"     // A games.json file, import only
+)
+
+// Entries of a library bundle
+const (
+	bundleGames    = "games.json"
+	bundleSettings = "settings.json"
+	bundleImages   = "images/"
+)
+
+// csvColumns are the columns of a CSV export, in order
+var csvColumns = []string{"name", "executable", "source_url", "current_version", "latest_version", "tags"}
+
+// csvTagSeparator joins the tags of a game in a single CSV cell
+const csvTagSeparator = ";"
+
+// FormatFromPath picks the export or import format from the file extension
+func FormatFromPath(filePath string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".zip":
+		return FormatBundle, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown library file type %q, use .zip, .csv or .md", filepath.Ext(filePath))
+	}
+}
+
+// Export writes games to filePath in the format given by its extension. A
+// bundle also contains the settings and the images of the games.
+func (m *Manager) Export(filePath string, games []*models.Game, settings *models.Settings) error {
+	format, err := FormatFromPath(filePath)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case FormatBundle:
+		err = ExportBundle(&buf, games, settings)
+	case FormatCSV:
+		err = ExportCSV(&buf, games)
+	case FormatMarkdown:
+		err = ExportMarkdown(&buf, games)
+	default:
+		return fmt.Errorf("cannot export to %s files", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filePath, buf.Bytes(), 0644)
+}
+
+// ExportBundle writes a zip holding games.json, settings.json and the game
+// images. Image paths inside the bundle are relative so it can be imported
+// on another machine.
+func ExportBundle(w io.Writer, games []*models.Game, settings *models.Settings) error {
+	zw := zip.NewWriter(w)
+
+	exported := make([]*models.Game, 0, len(games))
+	used := make(map[string]bool)
+	for _, game := range games {
+		copied := *game
+		if name, ok := bundleImageName(game, used); ok {
+			if err := addFileToZip(zw, bundleImages+name, game.ImagePath); err != nil {
+				fmt.Printf("Warning: Not exporting image of %s: %v\n", game.Name, err)
+				copied.ImagePath = ""
+			} else {
+				copied.ImagePath = bundleImages + name
+			}
+		}
+		exported = append(exported, &copied)
+	}
+
+	data, err := encodeGames(exported)
+	if err != nil {
+		return err
+	}
+	if err := addBytesToZip(zw, bundleGames, data); err != nil {
+		return err
+	}
+
+	if settings != nil {
+		data, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := addBytesToZip(zw, bundleSettings, data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// bundleImageName returns a unique name for the image of game inside the
+// bundle, or false if the game has no image on disk
+func bundleImageName(game *models.Game, used map[string]bool) (string, bool) {
+	if game.ImagePath == "" {
+		return "", false
+	}
+	if info, err := os.Stat(game.ImagePath); err != nil || info.IsDir() {
+		return "", false
+	}
+
+	name := filepath.Base(game.ImagePath)
+	if used[name] {
+		name = game.ID + "-" + name
+	}
+	used[name] = true
+	return name, true
+}
+
+func addBytesToZip(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+func addFileToZip(zw *zip.Writer, name, filePath string) error {
+	src, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, src)
+	return err
+}
+
+// ExportCSV writes one row per game with the columns in csvColumns
+func ExportCSV(w io.Writer, games []*models.Game) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+
+	for _, game := range games {
+		row := []string{
+			game.Name,
+			game.Executable,
+			game.SourceURL,
+			game.CurrentVersion,
+			game.Version,
+			strings.Join(game.Tags, csvTagSeparator),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportMarkdown writes a report of the library as a Markdown table
+func ExportMarkdown(w io.Writer, games []*models.Game) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Game Library\n\n")
+	fmt.Fprintf(&b, "%d games, exported %s.\n\n", len(games), time.Now().Format("2006-01-02 15:04"))
+	b.WriteString("| # | Name | Installed | Latest | Status | Tags | Source |\n")
+	b.WriteString("|---|------|-----------|--------|--------|------|--------|\n")
+
+	for i, game := range games {
+		latest := game.Version
+		if latest != "" && game.CurrentVersion != "" && version.IsNewer(latest, game.CurrentVersion) {
+			latest += " (update)"
+		}
+
+		source := ""
+		if game.SourceURL != "" {
+			source = fmt.Sprintf("[link](%s)", game.SourceURL)
+		}
+
+		fmt.Fprintf(&b, "| %d | %s | %s | %s | %s | %s | %s |\n",
+			i+1,
+			markdownCell(game.Name),
+			markdownCell(game.CurrentVersion),
+			markdownCell(latest),
+			markdownCell(game.SourceStatus),
+			markdownCell(strings.Join(game.Tags, ", ")),
+			source)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell escapes text for use inside a Markdown table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
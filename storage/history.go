@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"encoding/json"
+	"gamelauncher/models"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// historyFile holds the versions observed per game ID, next to games.json
+const historyFile = "history.json"
+
+// RecordVersion adds a version to the game's history unless it was already
+// seen. It reports whether the record was new.
+func (m *Manager) RecordVersion(gameID string, record models.VersionRecord) (bool, error) {
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+
+	// Other launcher processes may be recording versions too
+	unlock, err := m.lockData()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	history, err := m.loadHistory()
+	if err != nil {
+		return false, err
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(record.Version))
+	if normalized == "" {
+		return false, nil
+	}
+	for _, existing := range history[gameID] {
+		if strings.ToLower(strings.TrimSpace(existing.Version)) == normalized {
+			return false, nil
+		}
+	}
+
+	history[gameID] = append(history[gameID], record)
+	return true, m.saveHistory(history)
+}
+
+// VersionHistory returns the versions seen for a game, oldest first
+func (m *Manager) VersionHistory(gameID string) ([]models.VersionRecord, error) {
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+
+	history, err := m.loadHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	records := append([]models.VersionRecord(nil), history[gameID]...)
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].FirstSeen.Before(records[j].FirstSeen)
+	})
+	return records, nil
+}
+
+// DeleteVersionHistory forgets all versions recorded for a game
+func (m *Manager) DeleteVersionHistory(gameID string) error {
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+
+	// Other launcher processes may be recording versions too
+	unlock, err := m.lockData()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	history, err := m.loadHistory()
+	if err != nil {
+		return err
+	}
+	if _, ok := history[gameID]; !ok {
+		return nil
+	}
+
+	delete(history, gameID)
+	return m.saveHistory(history)
+}
+
+// loadHistory reads the history file; callers must hold historyMu
+func (m *Manager) loadHistory() (map[string][]models.VersionRecord, error) {
+	history := make(map[string][]models.VersionRecord)
+
+	data, err := os.ReadFile(filepath.Join(m.dataPath, historyFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// saveHistory writes the history file; callers must hold historyMu
+func (m *Manager) saveHistory(history map[string][]models.VersionRecord) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(m.dataPath, historyFile), data, 0644)
+}
@@ -0,0 +1,415 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gamelauncher/datadir"
+	"gamelauncher/models"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxBundleEntrySize limits how much is read from a single bundle entry
+const maxBundleEntrySize = 64 << 20
+
+// PathMapping rewrites a path prefix of imported games, e.g. a library
+// exported from D:\Games and imported under /mnt/games
+type PathMapping struct {
+	From string
+	To   string
+}
+
+// ParsePathMapping parses a mapping written as FROM=TO
+func ParsePathMapping(s string) (PathMapping, error) {
+	from, to, ok := strings.Cut(s, "=")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if !ok || from == "" || to == "" {
+		return PathMapping{}, fmt.Errorf("invalid path mapping %q, expected FROM=TO", s)
+	}
+	return PathMapping{From: from, To: to}, nil
+}
+
+// Apply returns p with the From prefix replaced by To. Separators in the
+// rest of the path are converted to the style used by To. Windows prefixes
+// are compared ignoring case.
+func (pm PathMapping) Apply(p string) (string, bool) {
+	from := strings.TrimRight(toSlash(pm.From), "/")
+	candidate := toSlash(p)
+
+	if len(candidate) < len(from) {
+		return p, false
+	}
+	prefix := candidate[:len(from)]
+	if prefix != from && !(windowsPath(pm.From) && strings.EqualFold(prefix, from)) {
+		return p, false
+	}
+	rest := candidate[len(from):]
+	if rest != "" && rest[0] != '/' {
+		return p, false
+	}
+
+	to := strings.TrimRight(pm.To, `/\`)
+	if windowsPath(pm.To) {
+		rest = strings.ReplaceAll(rest, "/", `\`)
+	}
+	return to + rest, true
+}
+
+// toSlash converts both Windows and Unix separators to forward slashes,
+// independent of the platform the launcher runs on
+func toSlash(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+}
+
+// windowsPath reports whether p looks like a Windows path
+func windowsPath(p string) bool {
+	return strings.Contains(p, `\`) || (len(p) >= 2 && p[1] == ':')
+}
+
+// ImportOptions controls how a library file is imported
+type ImportOptions struct {
+	PathMappings []PathMapping // Applied to executables, folders, icons and images
+	Settings     bool          // Also return the settings of a bundle
+}
+
+// ImportResult holds the games read from a library file. Nothing is saved;
+// the caller adds Games to its library.
+type ImportResult struct {
+	Games      []*models.Game   // New games, with fresh IDs where needed
+	Duplicates []*models.Game   // Games skipped because the library already has them
+	Settings   *models.Settings // Settings from a bundle, if requested
+}
+
+// Import reads the library file at filePath (a .zip bundle, a .csv file or
+// a games.json) and returns the games that are not yet in existing. Images
+// in a bundle are copied into the data directory.
+func (m *Manager) Import(filePath string, existing []*models.Game, opts ImportOptions) (*ImportResult, error) {
+	format, err := FormatFromPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		games  []*models.Game
+		images map[string]*zip.File
+		result = &ImportResult{}
+	)
+
+	switch format {
+	case FormatBundle:
+		zr, err := zip.OpenReader(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open bundle %s: %w", filePath, err)
+		}
+		defer zr.Close()
+
+		var settings *models.Settings
+		games, settings, images, err = readBundle(filePath, &zr.Reader)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Settings {
+			result.Settings = settings
+		}
+	case FormatCSV:
+		f, err := os.Open(filePath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		if games, err = ImportCSV(f); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+	case FormatJSON:
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		if games, _, err = decodeGames(filePath, data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cannot import %s files", format)
+	}
+
+	for _, game := range games {
+		applyPathMappings(game, opts.PathMappings)
+	}
+
+	result.Games, result.Duplicates = FindDuplicates(existing, games)
+
+	ids := make(map[string]bool, len(existing))
+	for _, game := range existing {
+		ids[game.ID] = true
+	}
+	for _, game := range result.Games {
+		if game.ID == "" || ids[game.ID] {
+			game.ID = uuid.New().String()
+		}
+		ids[game.ID] = true
+
+		if format != FormatBundle || !strings.HasPrefix(game.ImagePath, bundleImages) {
+			continue
+		}
+		if f, ok := images[game.ImagePath]; ok {
+			game.ImagePath, err = m.extractImage(f)
+			if err != nil {
+				fmt.Printf("Warning: Failed to import image of %s: %v\n", game.Name, err)
+				game.ImagePath = ""
+			}
+		} else {
+			game.ImagePath = ""
+		}
+	}
+
+	if result.Settings != nil {
+		if mapped, ok := mapPath(result.Settings.LastUsedPath, opts.PathMappings); ok {
+			result.Settings.LastUsedPath = mapped
+		}
+	}
+
+	return result, nil
+}
+
+// readBundle reads the games, settings and image entries of a bundle
+func readBundle(filePath string, zr *zip.Reader) ([]*models.Game, *models.Settings, map[string]*zip.File, error) {
+	var (
+		games    []*models.Game
+		settings *models.Settings
+		found    bool
+	)
+	images := make(map[string]*zip.File)
+
+	for _, f := range zr.File {
+		switch {
+		case f.Name == bundleGames:
+			data, err := readZipFile(f)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if games, _, err = decodeGames(filePath+":"+bundleGames, data); err != nil {
+				return nil, nil, nil, err
+			}
+			found = true
+		case f.Name == bundleSettings:
+			data, err := readZipFile(f)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			settings = models.DefaultSettings()
+			if err := json.Unmarshal(data, settings); err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid %s in %s: %w", bundleSettings, filePath, err)
+			}
+		case strings.HasPrefix(f.Name, bundleImages) && !f.FileInfo().IsDir():
+			images[f.Name] = f
+		}
+	}
+
+	if !found {
+		return nil, nil, nil, fmt.Errorf("%s is not a library bundle: %s is missing", filePath, bundleGames)
+	}
+	return games, settings, images, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxBundleEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBundleEntrySize {
+		return nil, fmt.Errorf("%s is too large", f.Name)
+	}
+	return data, nil
+}
+
+// extractImage copies an image from a bundle into the images directory and
+// returns its path. An identical existing file is reused; a different file
+// with the same name is left alone.
+func (m *Manager) extractImage(f *zip.File) (string, error) {
+	data, err := readZipFile(f)
+	if err != nil {
+		return "", err
+	}
+
+	dir := datadir.ImagesDir(m.dataPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	// Only the base name is used, so entries cannot escape the directory
+	name := path.Base(toSlash(f.Name))
+	if name == "." || name == ".." || name == "/" {
+		return "", fmt.Errorf("invalid image name %q", f.Name)
+	}
+	target := filepath.Join(dir, name)
+	for i := 1; ; i++ {
+		existing, err := os.ReadFile(target)
+		if errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		if err == nil && bytes.Equal(existing, data) {
+			return target, nil
+		}
+		ext := filepath.Ext(name)
+		target = filepath.Join(dir, fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), i, ext))
+	}
+
+	if err := writeFileAtomic(target, data, 0644); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// ImportCSV reads games from CSV with a header row. Columns are matched by
+// the names in csvColumns, so they may be reordered or left out.
+func ImportCSV(r io.Reader) ([]*models.Game, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("file is empty")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		if _, ok := columns["executable"]; !ok {
+			return nil, fmt.Errorf("header needs a name or executable column")
+		}
+	}
+
+	var games []*models.Game
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		name, executable := field("name"), field("executable")
+		if name == "" && executable == "" {
+			continue
+		}
+		if name == "" {
+			base := path.Base(toSlash(executable))
+			name = strings.TrimSuffix(base, path.Ext(base))
+		}
+
+		game := models.NewGame(name, executable, pathDir(executable))
+		game.SourceURL = field("source_url")
+		game.CurrentVersion = field("current_version")
+		game.Version = field("latest_version")
+		for _, tag := range strings.Split(field("tags"), csvTagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				game.Tags = append(game.Tags, tag)
+			}
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+// pathDir returns the directory of p for Windows and Unix paths alike
+func pathDir(p string) string {
+	if p == "" {
+		return ""
+	}
+	i := strings.LastIndexAny(p, `/\`)
+	if i < 0 {
+		return ""
+	}
+	return p[:i]
+}
+
+// applyPathMappings rewrites the local paths of game using the first
+// matching mapping for each path
+func applyPathMappings(game *models.Game, mappings []PathMapping) {
+	for _, p := range []*string{&game.Executable, &game.Folder, &game.IconPath, &game.ImagePath} {
+		if mapped, ok := mapPath(*p, mappings); ok {
+			*p = mapped
+		}
+	}
+}
+
+func mapPath(p string, mappings []PathMapping) (string, bool) {
+	if p == "" {
+		return p, false
+	}
+	for _, mapping := range mappings {
+		if mapped, ok := mapping.Apply(p); ok {
+			return mapped, true
+		}
+	}
+	return p, false
+}
+
+// FindDuplicates splits imported into games that are new and games already
+// in existing (or earlier in imported), matching by name or executable
+func FindDuplicates(existing, imported []*models.Game) (added, duplicates []*models.Game) {
+	names := make(map[string]bool)
+	executables := make(map[string]bool)
+	remember := func(game *models.Game) {
+		if key := nameKey(game.Name); key != "" {
+			names[key] = true
+		}
+		if key := executableKey(game.Executable); key != "" {
+			executables[key] = true
+		}
+	}
+	for _, game := range existing {
+		remember(game)
+	}
+
+	for _, game := range imported {
+		if names[nameKey(game.Name)] || executables[executableKey(game.Executable)] {
+			duplicates = append(duplicates, game)
+			continue
+		}
+		remember(game)
+		added = append(added, game)
+	}
+	return added, duplicates
+}
+
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func executableKey(executable string) string {
+	if executable == "" {
+		return ""
+	}
+	return strings.ToLower(path.Clean(toSlash(executable)))
+}
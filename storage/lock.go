@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// lockFileName is the advisory lock shared by every launcher process
+	// (GUI and CLI) using the same data directory
+	lockFileName = ".lock"
+
+	// lockTimeout bounds how long a save waits for another process
+	lockTimeout = 10 * time.Second
+)
+
+// lockData serialises access to the data directory, both between goroutines
+// of this process and between processes. The returned function releases the
+// lock.
+func (m *Manager) lockData() (func(), error) {
+	m.saveMu.Lock()
+
+	f, err := os.OpenFile(filepath.Join(m.dataPath, lockFileName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		m.saveMu.Unlock()
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		err = tryLockFile(f)
+		if err == nil {
+			break
+		}
+		if !isLockBusy(err) || time.Now().After(deadline) {
+			f.Close()
+			m.saveMu.Unlock()
+			if isLockBusy(err) {
+				return nil, fmt.Errorf("game library is locked by another launcher process")
+			}
+			return nil, fmt.Errorf("failed to lock game library: %w", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return func() {
+		unlockFile(f)
+		f.Close()
+		m.saveMu.Unlock()
+	}, nil
+}
@@ -0,0 +1,21 @@
+//go:build (!unix && !windows) || aix
+
+package storage
+
+import "os"
+
+// tryLockFile does nothing where advisory file locks are not available, such
+// as js/wasm and plan9; saves of this process are still serialised by saveMu
+func tryLockFile(f *os.File) error {
+	return nil
+}
+
+// unlockFile releases a lock taken by tryLockFile
+func unlockFile(f *os.File) error {
+	return nil
+}
+
+// isLockBusy reports whether err means another process holds the lock
+func isLockBusy(err error) bool {
+	return false
+}
@@ -0,0 +1,25 @@
+//go:build unix && !aix
+
+package storage
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// tryLockFile takes an exclusive advisory lock on f without blocking
+func tryLockFile(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+}
+
+// unlockFile releases a lock taken by tryLockFile
+func unlockFile(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
+
+// isLockBusy reports whether err means another process holds the lock
+func isLockBusy(err error) bool {
+	return errors.Is(err, unix.EWOULDBLOCK)
+}
@@ -2,64 +2,186 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"gamelauncher/datadir"
 	"gamelauncher/models"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
 // Manager handles data persistence
 type Manager struct {
-	dataPath string
-}
+	dataPath  string
+	historyMu sync.Mutex // Serialises read-modify-write of the version history
+	sessionMu sync.Mutex // Serialises read-modify-write of the play sessions
 
-// NewManager creates a new storage manager
-func NewManager() *Manager {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		homeDir = "."
-	}
+	saveMu      sync.Mutex // Serialises saves and backup rotation
+	backupCount int        // Number of games.json backups to keep
+	snapshot    snapshot   // games.json as last loaded or saved, for merging
+	oldSchema   bool       // An older games.json schema was already reported
+}
 
-	dataPath := filepath.Join(homeDir, ".gamelauncher")
+// NewManager creates a storage manager keeping its files in dataPath, as
+// resolved by datadir.Resolve
+func NewManager(dataPath string) *Manager {
 	if err := os.MkdirAll(dataPath, 0755); err != nil {
-		// Fallback to current directory
-		dataPath = "."
+		fmt.Printf("Warning: Failed to create data directory %s: %v\n", dataPath, err)
 	}
 
 	// Ensure images subdirectory exists for image storage
-	imagesPath := filepath.Join(dataPath, "images")
+	imagesPath := datadir.ImagesDir(dataPath)
 	if err := os.MkdirAll(imagesPath, 0755); err != nil {
 		fmt.Printf("Warning: Failed to create images directory: %v\n", err)
 	}
 
 	return &Manager{
-		dataPath: dataPath,
+		dataPath:    dataPath,
+		backupCount: DefaultBackupCount,
 	}
 }
 
-// SaveGames saves the games list to disk
+// gamesPath returns the location of games.json
+func (m *Manager) gamesPath() string {
+	return filepath.Join(m.dataPath, "games.json")
+}
+
+// SaveGames saves the games list to disk, merging in changes that another
+// launcher process saved in the meantime
 func (m *Manager) SaveGames(games []*models.Game) error {
+	_, err := m.SyncGames(games)
+	return err
+}
+
+// SyncGames saves the games list like SaveGames and returns the list that was
+// actually written, which includes games added or edited by other processes
+func (m *Manager) SyncGames(games []*models.Game) ([]*models.Game, error) {
 	fmt.Printf("DEBUG: SaveGames called with %d games\n", len(games))
 	for i, game := range games {
 		fmt.Printf("DEBUG: Game %d: %s (SourceURL: %s)\n", i+1, game.Name, game.SourceURL)
 	}
 
-	data, err := json.MarshalIndent(games, "", "  ")
+	filePath := m.gamesPath()
+	fmt.Printf("DEBUG: Saving games to %s\n", filePath)
+
+	unlock, err := m.lockData()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Never replace a library written by a newer launcher with an older schema
+	if err := checkWritable(filePath); err != nil {
+		return nil, err
+	}
+
+	games = m.mergeWithDisk(filePath, games)
+	data, err := m.writeGamesLocked(filePath, games)
+	if err != nil {
+		return nil, err
+	}
+	m.recordSnapshot(filePath, data, games)
+	return games, nil
+}
+
+// replaceGames writes games as the whole library without merging in what is
+// on disk, for when games.json is known to be out of date
+func (m *Manager) replaceGames(games []*models.Game) error {
+	filePath := m.gamesPath()
+
+	unlock, err := m.lockData()
 	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	filePath := filepath.Join(m.dataPath, "games.json")
-	fmt.Printf("DEBUG: Saving games to %s\n", filePath)
-	return os.WriteFile(filePath, data, 0644)
+	if err := checkWritable(filePath); err != nil {
+		return err
+	}
+
+	data, err := m.writeGamesLocked(filePath, games)
+	if err != nil {
+		return err
+	}
+	m.recordSnapshot(filePath, data, games)
+	return nil
+}
+
+// updateGames applies change to the games currently on disk and writes the
+// result, so games changed by other processes are left untouched. id is the
+// game change adds, replaces or removes.
+func (m *Manager) updateGames(id string, change func(games []*models.Game) []*models.Game) error {
+	filePath := m.gamesPath()
+
+	unlock, err := m.lockData()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := checkWritable(filePath); err != nil {
+		return err
+	}
+
+	games := []*models.Game{}
+	unchanged := false // Nobody else wrote the file since our snapshot
+	if data, err := os.ReadFile(filePath); err == nil {
+		if games, _, err = decodeGames(filePath, data); err != nil {
+			return fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		unchanged = hashBytes(data) == m.snapshot.hash
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	games = change(games)
+	data, err := m.writeGamesLocked(filePath, games)
+	if err != nil {
+		return err
+	}
+	if unchanged {
+		m.recordSnapshot(filePath, data, games)
+	} else {
+		// The file now holds games this process has not loaded; only the
+		// game written is known, so Changed still reports the others
+		m.recordGameSnapshot(id, games)
+	}
+	return nil
+}
+
+// writeGamesLocked backs up and replaces the games file and returns what
+// was written. Callers must hold the data lock.
+func (m *Manager) writeGamesLocked(filePath string, games []*models.Game) ([]byte, error) {
+	data, err := encodeGames(games)
+	if err != nil {
+		return nil, err
+	}
+
+	// A failed backup must not prevent saving the user's changes
+	if err := m.backupGames(filePath); err != nil {
+		fmt.Printf("Warning: Failed to back up games file: %v\n", err)
+	}
+
+	if err := writeFileAtomic(filePath, data, 0644); err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
-// LoadGames loads the games list from disk
+// LoadGames loads the games list from disk. If the file is corrupt the newest
+// valid backup is restored and returned along with a *RecoveryError.
 func (m *Manager) LoadGames() ([]*models.Game, error) {
-	filePath := filepath.Join(m.dataPath, "games.json")
+	filePath := m.gamesPath()
 	fmt.Printf("DEBUG: Loading games from %s\n", filePath)
 
+	unlock, err := m.lockData()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -69,9 +191,22 @@ func (m *Manager) LoadGames() ([]*models.Game, error) {
 		return nil, err
 	}
 
-	var games []*models.Game
-	if err := json.Unmarshal(data, &games); err != nil {
+	games, schema, err := decodeGames(filePath, data)
+	var recovered error
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
 		return nil, err
+	} else if err != nil {
+		fmt.Printf("Warning: Failed to parse %s: %v\n", filePath, err)
+		games, recovered = m.recoverGames(filePath, err)
+		if games == nil {
+			return nil, recovered
+		}
+		data, _ = os.ReadFile(filePath)
+	} else if schema < CurrentSchema && !m.oldSchema {
+		fmt.Printf("Warning: %s uses schema version %d, it is upgraded to %d on the next save\n",
+			filePath, schema, CurrentSchema)
+		m.oldSchema = true
 	}
 
 	fmt.Printf("DEBUG: Loaded %d games from file\n", len(games))
@@ -85,7 +220,9 @@ func (m *Manager) LoadGames() ([]*models.Game, error) {
 		game.Folder = m.cleanPath(game.Folder)
 	}
 
-	return games, nil
+	m.recordSnapshot(filePath, data, games)
+
+	return games, recovered
 }
 
 // SaveSettings saves the settings to disk
@@ -96,7 +233,7 @@ func (m *Manager) SaveSettings(settings *models.Settings) error {
 	}
 
 	filePath := filepath.Join(m.dataPath, "settings.json")
-	return os.WriteFile(filePath, data, 0644)
+	return writeFileAtomic(filePath, data, 0644)
 }
 
 // LoadSettings loads the settings from disk
@@ -111,12 +248,41 @@ func (m *Manager) LoadSettings() (*models.Settings, error) {
 		return nil, err
 	}
 
-	var settings models.Settings
-	if err := json.Unmarshal(data, &settings); err != nil {
+	// Start from the defaults so settings added since the file was written
+	// get sensible values
+	settings := models.DefaultSettings()
+	if err := json.Unmarshal(data, settings); err != nil {
 		return nil, err
 	}
 
-	return &settings, nil
+	return settings, nil
+}
+
+// DataPath returns the directory the manager keeps its files in
+func (m *Manager) DataPath() string {
+	return m.dataPath
+}
+
+// DeleteImage removes the downloaded image of a game from the images
+// directory. Images elsewhere, chosen by the user, and images other games
+// still use are left alone.
+func (m *Manager) DeleteImage(game *models.Game, games []*models.Game) error {
+	if game.ImagePath == "" {
+		return nil
+	}
+	image := m.cleanPath(game.ImagePath)
+	if filepath.Dir(image) != filepath.Clean(datadir.ImagesDir(m.dataPath)) {
+		return nil
+	}
+	for _, other := range games {
+		if other.ID != game.ID && other.ImagePath != "" && m.cleanPath(other.ImagePath) == image {
+			return nil
+		}
+	}
+	if err := os.Remove(image); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
 // cleanPath cleans and normalizes a file path
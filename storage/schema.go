@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gamelauncher/models"
+	"os"
+)
+
+// Schema versions of games.json. Version 1 is the original bare JSON array of
+// games; later versions wrap the games in a gamesFile envelope.
+const (
+	legacySchema = 1
+
+	// CurrentSchema is the games.json schema written by this build
+	CurrentSchema = 2
+)
+
+// gamesFile is the envelope games.json is stored in
+type gamesFile struct {
+	Schema int             `json:"schema"`
+	Games  json.RawMessage `json:"games"`
+}
+
+// migration upgrades the raw games array of one schema version to the next.
+// Migrations work on raw JSON so they can still read fields the current models
+// renamed or removed. Fields the models don't know are dropped once the games
+// are decoded, and are gone after the next save.
+type migration func(games json.RawMessage) (json.RawMessage, error)
+
+// migrations[i] upgrades schema i+1 to schema i+2
+var migrations = []migration{
+	// 1 -> 2: games moved into the versioned envelope, no field changes
+	func(games json.RawMessage) (json.RawMessage, error) { return games, nil },
+}
+
+// SchemaError is returned when games.json was written by a newer launcher
+// using a schema this build does not understand
+type SchemaError struct {
+	Path      string
+	Schema    int // Schema found in the file
+	Supported int // Newest schema this build can read
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s uses schema version %d, but this version of the launcher only supports up to %d; please update the launcher",
+		e.Path, e.Schema, e.Supported)
+}
+
+// decodeGames parses games.json in any supported schema, upgrading older
+// files through the migration chain. It reports the schema the data was
+// stored in.
+func decodeGames(path string, data []byte) ([]*models.Game, int, error) {
+	schema, raw, err := readSchema(path, data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for v := schema; v < CurrentSchema; v++ {
+		raw, err = migrations[v-1](raw)
+		if err != nil {
+			return nil, schema, fmt.Errorf("failed to migrate %s from schema %d to %d: %w", path, v, v+1, err)
+		}
+	}
+
+	var games []*models.Game
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &games); err != nil {
+			return nil, schema, err
+		}
+	}
+	if games == nil {
+		games = []*models.Game{}
+	}
+	return games, schema, nil
+}
+
+// readSchema splits the file into its schema version and raw games array
+func readSchema(path string, data []byte) (int, json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return legacySchema, trimmed, nil
+	}
+
+	var file gamesFile
+	if err := json.Unmarshal(trimmed, &file); err != nil {
+		return 0, nil, err
+	}
+	if file.Schema < legacySchema {
+		return 0, nil, fmt.Errorf("%s has no valid schema version", path)
+	}
+	if file.Schema > CurrentSchema {
+		return 0, nil, &SchemaError{Path: path, Schema: file.Schema, Supported: CurrentSchema}
+	}
+	return file.Schema, file.Games, nil
+}
+
+// encodeGames wraps the games in the current schema's envelope
+func encodeGames(games []*models.Game) ([]byte, error) {
+	if games == nil {
+		games = []*models.Game{}
+	}
+	return json.MarshalIndent(struct {
+		Schema int            `json:"schema"`
+		Games  []*models.Game `json:"games"`
+	}{CurrentSchema, games}, "", "  ")
+}
+
+// checkWritable refuses to overwrite a games file that was written with a
+// schema newer than this build understands
+func checkWritable(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var schemaErr *SchemaError
+	if _, _, err := readSchema(path, data); errors.As(err, &schemaErr) {
+		return err
+	}
+	return nil
+}
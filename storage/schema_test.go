@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// copyFixture copies a file from testdata to games.json in a new data folder
+func copyFixture(t *testing.T, name string) *Manager {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "games.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return NewManager(dir)
+}
+
+func TestDecodeLegacySchema(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "games_v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	games, schema, err := decodeGames("games_v1.json", data)
+	if err != nil {
+		t.Fatalf("decodeGames: %v", err)
+	}
+	if schema != legacySchema {
+		t.Errorf("schema = %d, want %d", schema, legacySchema)
+	}
+	if len(games) != 2 {
+		t.Fatalf("decoded %d games, want 2", len(games))
+	}
+	alpha := games[0]
+	if alpha.Name != "Alpha Quest" || alpha.CurrentVersion != "v0.7" || !alpha.IsInstalled ||
+		alpha.SourceURL != "https://f95zone.to/threads/alpha-quest.1234/" {
+		t.Errorf("decoded game = %+v, want the fields of the fixture", alpha)
+	}
+}
+
+func TestLegacySchemaRoundTrip(t *testing.T) {
+	m := copyFixture(t, "games_v1.json")
+
+	loaded, err := m.LoadGames()
+	if err != nil {
+		t.Fatalf("LoadGames: %v", err)
+	}
+	if err := m.SaveGames(loaded); err != nil {
+		t.Fatalf("SaveGames: %v", err)
+	}
+
+	data, err := os.ReadFile(m.gamesPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, _, err := readSchema(m.gamesPath(), data)
+	if err != nil || schema != CurrentSchema {
+		t.Fatalf("saved schema = %d (%v), want %d", schema, err, CurrentSchema)
+	}
+
+	saved, err := NewManager(m.DataPath()).LoadGames()
+	if err != nil {
+		t.Fatalf("LoadGames after saving: %v", err)
+	}
+	if !reflect.DeepEqual(saved, loaded) {
+		t.Errorf("games changed in the round trip:\n got %+v\nwant %+v", saved[0], loaded[0])
+	}
+
+	// Fields the models don't know are dropped, as documented on migration
+	if bytes.Contains(data, []byte("legacy_rating")) {
+		t.Error("unknown field legacy_rating was written back")
+	}
+}
+
+func TestNewerSchemaIsRefused(t *testing.T) {
+	m := copyFixture(t, "games_v1.json")
+	if err := os.WriteFile(m.gamesPath(), []byte(`{"schema": 99, "games": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var schemaErr *SchemaError
+	if _, err := m.LoadGames(); !errors.As(err, &schemaErr) {
+		t.Errorf("LoadGames error = %v, want a *SchemaError", err)
+	}
+	if err := m.SaveGames(nil); !errors.As(err, &schemaErr) {
+		t.Errorf("SaveGames error = %v, want a *SchemaError", err)
+	}
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"encoding/json"
+	"gamelauncher/models"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// sessionsFile holds the play sessions per game ID, next to games.json
+const sessionsFile = "sessions.json"
+
+// RecordSession appends a finished play session to the game's sessions
+func (m *Manager) RecordSession(gameID string, session models.Session) error {
+	m.sessionMu.Lock()
+	defer m.sessionMu.Unlock()
+
+	// Other launcher processes may be recording sessions too
+	unlock, err := m.lockData()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	sessions, err := m.loadSessions()
+	if err != nil {
+		return err
+	}
+
+	sessions[gameID] = append(sessions[gameID], session)
+	return m.saveSessions(sessions)
+}
+
+// Sessions returns the play sessions recorded for a game, oldest first
+func (m *Manager) Sessions(gameID string) ([]models.Session, error) {
+	m.sessionMu.Lock()
+	defer m.sessionMu.Unlock()
+
+	sessions, err := m.loadSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	records := append([]models.Session(nil), sessions[gameID]...)
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Start.Before(records[j].Start)
+	})
+	return records, nil
+}
+
+// DeleteSessions forgets all play sessions recorded for a game
+func (m *Manager) DeleteSessions(gameID string) error {
+	m.sessionMu.Lock()
+	defer m.sessionMu.Unlock()
+
+	unlock, err := m.lockData()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	sessions, err := m.loadSessions()
+	if err != nil {
+		return err
+	}
+	if _, ok := sessions[gameID]; !ok {
+		return nil
+	}
+
+	delete(sessions, gameID)
+	return m.saveSessions(sessions)
+}
+
+// loadSessions reads the sessions file; callers must hold sessionMu
+func (m *Manager) loadSessions() (map[string][]models.Session, error) {
+	sessions := make(map[string][]models.Session)
+
+	data, err := os.ReadFile(filepath.Join(m.dataPath, sessionsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return sessions, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// saveSessions writes the sessions file; callers must hold sessionMu
+func (m *Manager) saveSessions(sessions map[string][]models.Session) error {
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(m.dataPath, sessionsFile), data, 0644)
+}
@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gamelauncher/models"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteFile is the database used by the SQLite backend, next to games.json
+const sqliteFile = "games.db"
+
+// sqliteSchema creates the tables and indexes of the SQLite backend. Each
+// game is stored as JSON in the data column so new fields need no migration;
+// the other columns are copies used for indexing and queries.
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS games (
+		id         TEXT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		last_check INTEGER NOT NULL DEFAULT 0,
+		position   INTEGER NOT NULL,
+		data       TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS games_name ON games (name COLLATE NOCASE)`,
+	`CREATE INDEX IF NOT EXISTS games_last_check ON games (last_check)`,
+	`CREATE TABLE IF NOT EXISTS game_tags (
+		game_id TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+		tag     TEXT NOT NULL COLLATE NOCASE,
+		PRIMARY KEY (game_id, tag)
+	)`,
+	`CREATE INDEX IF NOT EXISTS game_tags_tag ON game_tags (tag)`,
+	`CREATE TABLE IF NOT EXISTS meta (
+		key   TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	)`,
+}
+
+// SQLiteStore keeps the game library in an embedded SQLite database
+type SQLiteStore struct {
+	db *sql.DB
+
+	mu          sync.Mutex
+	dataVersion int64 // PRAGMA data_version when last read, to detect other writers
+}
+
+var _ GameStore = (*SQLiteStore)(nil)
+
+// OpenSQLiteStore opens or creates the database at path
+func OpenSQLiteStore(path string) (*SQLiteStore, error) {
+	dsn := "file:" + path + "?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	// PRAGMA data_version is per connection, so keep exactly one
+	db.SetMaxOpenConns(1)
+
+	for _, stmt := range sqliteSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to initialise %s: %w", path, err)
+		}
+	}
+
+	store := &SQLiteStore{db: db}
+	store.dataVersion, _ = store.readDataVersion()
+	return store, nil
+}
+
+// Close closes the database
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// LoadGames returns every game in the order they were added
+func (s *SQLiteStore) LoadGames() ([]*models.Game, error) {
+	games, err := s.queryGames(`SELECT data FROM games ORDER BY position`)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.dataVersion, _ = s.readDataVersion()
+	s.mu.Unlock()
+
+	return games, nil
+}
+
+// SaveGame inserts or replaces a single game and its tags
+func (s *SQLiteStore) SaveGame(game *models.Game) error {
+	return s.SaveGames([]*models.Game{game})
+}
+
+// SaveGames inserts or replaces several games in a single transaction
+func (s *SQLiteStore) SaveGames(games []*models.Game) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, game := range games {
+		if err := saveGameTx(tx, game); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// saveGameTx writes a game row and its tags within tx
+func saveGameTx(tx *sql.Tx, game *models.Game) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO games (id, name, last_check, position, data)
+		VALUES (?, ?, ?, (SELECT COALESCE(MAX(position), 0) + 1 FROM games), ?)
+		ON CONFLICT (id) DO UPDATE SET name = excluded.name, last_check = excluded.last_check, data = excluded.data`,
+		game.ID, game.Name, unixTime(game.LastCheck), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", game.Name, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM game_tags WHERE game_id = ?`, game.ID); err != nil {
+		return err
+	}
+	for _, tag := range game.Tags {
+		if tag = strings.TrimSpace(tag); tag == "" {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO game_tags (game_id, tag) VALUES (?, ?)`, game.ID, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteGame removes a game and its tags
+func (s *SQLiteStore) DeleteGame(id string) error {
+	_, err := s.db.Exec(`DELETE FROM games WHERE id = ?`, id)
+	return err
+}
+
+// Query selects games using the name, tag and last-check indexes
+func (s *SQLiteStore) Query(q GameQuery) ([]*models.Game, error) {
+	var (
+		where []string
+		args  []any
+	)
+
+	if q.Name != "" {
+		where = append(where, `name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(q.Name)+"%")
+	}
+	if q.Tag != "" {
+		where = append(where, `id IN (SELECT game_id FROM game_tags WHERE tag = ?)`)
+		args = append(args, q.Tag)
+	}
+	if !q.CheckedBefore.IsZero() {
+		where = append(where, `last_check < ?`)
+		args = append(args, unixTime(q.CheckedBefore))
+	}
+
+	query := `SELECT data FROM games`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, ` AND `)
+	}
+	query += ` ORDER BY name COLLATE NOCASE`
+	if q.Limit > 0 {
+		query += fmt.Sprintf(` LIMIT %d`, q.Limit)
+	}
+
+	return s.queryGames(query, args...)
+}
+
+// Changed reports whether another connection wrote to the database since the
+// games were last loaded
+func (s *SQLiteStore) Changed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	version, err := s.readDataVersion()
+	if err != nil || version == s.dataVersion {
+		return false
+	}
+	s.dataVersion = version
+	return true
+}
+
+// ImportJSON copies the games of the JSON backend into a new database. It
+// runs once per database, so later changes to games.json never overwrite
+// games edited in SQLite; switching backends in the settings writes the
+// current library with WriteLibrary instead. It reports how many games were
+// imported.
+func (s *SQLiteStore) ImportJSON(m *Manager) (int, error) {
+	var imported string
+	err := s.db.QueryRow(`SELECT value FROM meta WHERE key = 'imported_json'`).Scan(&imported)
+	if err == nil {
+		return 0, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	var games []*models.Game
+	if _, err := os.Stat(m.gamesPath()); err == nil {
+		// A restore from backup still yields games worth importing
+		if games, err = m.LoadGames(); games == nil {
+			return 0, fmt.Errorf("failed to read games for import: %w", err)
+		}
+	}
+
+	if err := s.SaveGames(games); err != nil {
+		return 0, fmt.Errorf("failed to import games: %w", err)
+	}
+	if err := s.markImported(); err != nil {
+		return 0, err
+	}
+	return len(games), nil
+}
+
+// ReplaceGames makes games the whole library, in their order, removing every
+// other game from the database
+func (s *SQLiteStore) ReplaceGames(games []*models.Game) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM games`); err != nil {
+		return err
+	}
+	for _, game := range games {
+		if err := saveGameTx(tx, game); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	// The library is complete, games.json must not be imported on top of it
+	return s.markImported()
+}
+
+// markImported records that the database no longer needs games.json imported
+func (s *SQLiteStore) markImported() error {
+	_, err := s.db.Exec(`INSERT OR REPLACE INTO meta (key, value) VALUES ('imported_json', ?)`,
+		time.Now().Format(time.RFC3339))
+	return err
+}
+
+// queryGames runs a query selecting the data column and decodes the games
+func (s *SQLiteStore) queryGames(query string, args ...any) ([]*models.Game, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := []*models.Game{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var game models.Game
+		if err := json.Unmarshal([]byte(data), &game); err != nil {
+			return nil, fmt.Errorf("failed to decode stored game: %w", err)
+		}
+		games = append(games, &game)
+	}
+	return games, rows.Err()
+}
+
+func (s *SQLiteStore) readDataVersion() (int64, error) {
+	var version int64
+	err := s.db.QueryRow(`PRAGMA data_version`).Scan(&version)
+	return version, err
+}
+
+// unixTime stores zero times as 0 so never-checked games sort first
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied search string
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
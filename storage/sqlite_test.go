@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gamelauncher/models"
+)
+
+// openSQLite opens the SQLite backend of m and closes it when the test ends
+func openSQLite(t *testing.T, m *Manager) *SQLiteStore {
+	t.Helper()
+	store, err := OpenGameStore(m, BackendSQLite)
+	if err != nil {
+		t.Fatalf("OpenGameStore: %v", err)
+	}
+	sqlite := store.(*SQLiteStore)
+	t.Cleanup(func() { sqlite.Close() })
+	return sqlite
+}
+
+func gameNames(t *testing.T, store GameStore) map[string]string {
+	t.Helper()
+	games, err := store.LoadGames()
+	if err != nil {
+		t.Fatalf("LoadGames: %v", err)
+	}
+	names := make(map[string]string, len(games))
+	for _, game := range games {
+		names[game.ID] = game.Name
+	}
+	return names
+}
+
+func TestSQLiteImportsJSONOnce(t *testing.T) {
+	m := NewManager(t.TempDir())
+	if err := m.SaveGames([]*models.Game{{ID: "a", Name: "Alpha"}, {ID: "b", Name: "Beta"}}); err != nil {
+		t.Fatalf("SaveGames: %v", err)
+	}
+
+	store := openSQLite(t, m)
+	if names := gameNames(t, store); len(names) != 2 || names["a"] != "Alpha" {
+		t.Fatalf("imported games = %v, want Alpha and Beta", names)
+	}
+
+	// Edit only in SQLite, then have something rewrite games.json
+	if err := store.SaveGame(&models.Game{ID: "a", Name: "Alpha Edited"}); err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	if err := store.SaveGame(&models.Game{ID: "c", Name: "Gamma"}); err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	store.Close()
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(m.DataPath(), "games.json"), later, later); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	names := gameNames(t, openSQLite(t, m))
+	want := map[string]string{"a": "Alpha Edited", "b": "Beta", "c": "Gamma"}
+	if len(names) != len(want) {
+		t.Fatalf("games after reopening = %v, want %v", names, want)
+	}
+	for id, name := range want {
+		if names[id] != name {
+			t.Errorf("game %s = %q after reopening, want %q", id, names[id], name)
+		}
+	}
+}
+
+func TestWriteLibrary(t *testing.T) {
+	m := NewManager(t.TempDir())
+	if err := m.SaveGames([]*models.Game{{ID: "a", Name: "Alpha"}, {ID: "b", Name: "Beta"}}); err != nil {
+		t.Fatalf("SaveGames: %v", err)
+	}
+	openSQLite(t, m).Close()
+
+	// Games deleted and added while using JSON reach SQLite
+	library := []*models.Game{{ID: "b", Name: "Beta"}, {ID: "c", Name: "Gamma"}}
+	if err := WriteLibrary(m, BackendSQLite, library); err != nil {
+		t.Fatalf("WriteLibrary(sqlite): %v", err)
+	}
+	store := openSQLite(t, m)
+	if names := gameNames(t, store); len(names) != 2 || names["c"] != "Gamma" || names["a"] != "" {
+		t.Errorf("SQLite games = %v, want Beta and Gamma", names)
+	}
+
+	// and games deleted in SQLite don't come back in games.json
+	if err := WriteLibrary(m, BackendJSON, library[1:]); err != nil {
+		t.Fatalf("WriteLibrary(json): %v", err)
+	}
+	if names := gameNames(t, NewManager(m.DataPath())); len(names) != 1 || names["c"] != "Gamma" {
+		t.Errorf("games.json games = %v, want Gamma", names)
+	}
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"fmt"
+	"gamelauncher/models"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Storage backends selectable in the settings
+const (
+	BackendJSON   = "json"
+	BackendSQLite = "sqlite"
+)
+
+// GameStore persists the game library one game at a time, so a small change
+// doesn't require rewriting every game
+type GameStore interface {
+	// LoadGames returns every game in the library
+	LoadGames() ([]*models.Game, error)
+
+	// SaveGame inserts the game or replaces the stored game with the same ID
+	SaveGame(game *models.Game) error
+
+	// DeleteGame removes the game with the given ID, if present
+	DeleteGame(id string) error
+
+	// Query returns the games matching q
+	Query(q GameQuery) ([]*models.Game, error)
+}
+
+// ChangeDetector is implemented by stores that can tell when another launcher
+// process modified the library
+type ChangeDetector interface {
+	Changed() bool
+}
+
+// GameQuery selects games from a GameStore. Zero fields match every game.
+type GameQuery struct {
+	Name          string    // Case-insensitive substring of the game name
+	Tag           string    // Games carrying this tag, ignoring case
+	CheckedBefore time.Time // Games last checked before this time (or never)
+	Limit         int       // Maximum number of games returned
+}
+
+// Matches reports whether game satisfies the query
+func (q GameQuery) Matches(game *models.Game) bool {
+	if q.Name != "" && !strings.Contains(strings.ToLower(game.Name), strings.ToLower(q.Name)) {
+		return false
+	}
+	if q.Tag != "" && !game.HasTag(q.Tag) {
+		return false
+	}
+	if !q.CheckedBefore.IsZero() && !game.LastCheck.Before(q.CheckedBefore) {
+		return false
+	}
+	return true
+}
+
+// OpenGameStore returns the game store for the configured backend. Opening
+// the SQLite backend for the first time imports the existing games.json.
+func OpenGameStore(m *Manager, backend string) (GameStore, error) {
+	switch backend {
+	case "", BackendJSON:
+		return m, nil
+	case BackendSQLite:
+		store, err := OpenSQLiteStore(filepath.Join(m.dataPath, sqliteFile))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := store.ImportJSON(m); err != nil {
+			store.Close()
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
+
+// WriteLibrary writes games as the whole library of backend. Switching
+// backends in the settings calls it, so the new backend starts from the
+// library the old one held instead of from what it stored last time.
+func WriteLibrary(m *Manager, backend string, games []*models.Game) error {
+	switch backend {
+	case "", BackendJSON:
+		return m.replaceGames(games)
+	case BackendSQLite:
+		store, err := OpenSQLiteStore(filepath.Join(m.dataPath, sqliteFile))
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		return store.ReplaceGames(games)
+	default:
+		return fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
+
+// SaveAll stores every game in games with as few writes as the store allows.
+// For the JSON backend the returned list also contains games that other
+// processes added in the meantime; other stores return games unchanged.
+func SaveAll(store GameStore, games []*models.Game) ([]*models.Game, error) {
+	switch s := store.(type) {
+	case *Manager:
+		return s.SyncGames(games)
+	case *SQLiteStore:
+		return games, s.SaveGames(games)
+	}
+
+	for _, game := range games {
+		if err := store.SaveGame(game); err != nil {
+			return nil, err
+		}
+	}
+	return games, nil
+}
+
+// SaveGame stores a single game in games.json, leaving the other games as
+// they are on disk
+func (m *Manager) SaveGame(game *models.Game) error {
+	return m.updateGames(game.ID, func(games []*models.Game) []*models.Game {
+		for i, existing := range games {
+			if existing.ID == game.ID {
+				games[i] = game
+				return games
+			}
+		}
+		return append(games, game)
+	})
+}
+
+// DeleteGame removes a single game from games.json
+func (m *Manager) DeleteGame(id string) error {
+	return m.updateGames(id, func(games []*models.Game) []*models.Game {
+		for i, existing := range games {
+			if existing.ID == id {
+				return append(games[:i], games[i+1:]...)
+			}
+		}
+		return games
+	})
+}
+
+// Query loads games.json and filters it in memory
+func (m *Manager) Query(q GameQuery) ([]*models.Game, error) {
+	// A restore from backup still yields usable games
+	games, err := m.LoadGames()
+	if games == nil {
+		return nil, err
+	}
+
+	var matches []*models.Game
+	for _, game := range games {
+		if q.Matches(game) {
+			matches = append(matches, game)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return strings.ToLower(matches[i].Name) < strings.ToLower(matches[j].Name)
+	})
+	if q.Limit > 0 && len(matches) > q.Limit {
+		matches = matches[:q.Limit]
+	}
+	return matches, nil
+}
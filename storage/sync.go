@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"gamelauncher/models"
+	"os"
+	"time"
+)
+
+// snapshot remembers games.json as this manager last loaded or saved it, so
+// changes made by other processes can be detected and merged
+type snapshot struct {
+	hash    string
+	modTime time.Time
+	size    int64
+	games   map[string][]byte // Encoded games by ID, the base of three-way merges
+}
+
+// recordSnapshot stores the state of the games file after a load or save.
+// Callers must hold saveMu.
+func (m *Manager) recordSnapshot(filePath string, data []byte, games []*models.Game) {
+	snap := snapshot{
+		hash:  hashBytes(data),
+		games: make(map[string][]byte, len(games)),
+	}
+	if info, err := os.Stat(filePath); err == nil {
+		snap.modTime = info.ModTime()
+		snap.size = info.Size()
+	}
+	for _, game := range games {
+		if encoded, err := json.Marshal(game); err == nil {
+			snap.games[game.ID] = encoded
+		}
+	}
+	m.snapshot = snap
+}
+
+// recordGameSnapshot updates the merge base for the game with id after
+// writing it alone, leaving the file hash as it was so other processes'
+// changes are still detected. Callers must hold saveMu.
+func (m *Manager) recordGameSnapshot(id string, games []*models.Game) {
+	if m.snapshot.games == nil {
+		m.snapshot.games = make(map[string][]byte)
+	}
+	delete(m.snapshot.games, id)
+	for _, game := range games {
+		if game.ID != id {
+			continue
+		}
+		if encoded, err := json.Marshal(game); err == nil {
+			m.snapshot.games[id] = encoded
+		}
+	}
+}
+
+// Changed reports whether games.json was modified by another process since
+// this manager last loaded or saved it
+func (m *Manager) Changed() bool {
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+
+	filePath := m.gamesPath()
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return false
+	}
+	if info.ModTime().Equal(m.snapshot.modTime) && info.Size() == m.snapshot.size {
+		return false
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+	if hashBytes(data) == m.snapshot.hash {
+		// Touched but not changed; remember the new mtime to skip hashing next time
+		m.snapshot.modTime = info.ModTime()
+		m.snapshot.size = info.Size()
+		return false
+	}
+	return true
+}
+
+// mergeGames combines our games with the ones another process saved, using
+// base (the games as we last loaded or saved them) to tell who changed what.
+// When both sides changed the same game its fields are merged, and ours win
+// where both changed the same field.
+func mergeGames(base map[string][]byte, ours, theirs []*models.Game) []*models.Game {
+	theirsByID := make(map[string]*models.Game, len(theirs))
+	for _, game := range theirs {
+		theirsByID[game.ID] = game
+	}
+
+	var merged []*models.Game
+	oursByID := make(map[string]bool, len(ours))
+
+	for _, game := range ours {
+		oursByID[game.ID] = true
+		other, inTheirs := theirsByID[game.ID]
+		original, inBase := base[game.ID]
+
+		switch {
+		case !inBase:
+			// Added by us
+			merged = append(merged, game)
+		case !inTheirs:
+			// Deleted elsewhere; keep it only if we edited it since
+			if !encodesTo(game, original) {
+				merged = append(merged, game)
+			}
+		case encodesTo(game, original):
+			// Only the other process changed it (if anyone did)
+			merged = append(merged, other)
+		case encodesTo(other, original):
+			// Only we changed it
+			merged = append(merged, game)
+		default:
+			merged = append(merged, mergeFields(original, game, other))
+		}
+	}
+
+	for _, game := range theirs {
+		if oursByID[game.ID] {
+			continue
+		}
+		// Added elsewhere, or edited elsewhere after we deleted it
+		if original, inBase := base[game.ID]; !inBase || !encodesTo(game, original) {
+			merged = append(merged, game)
+		}
+	}
+
+	if merged == nil {
+		merged = []*models.Game{}
+	}
+	return merged
+}
+
+// mergeFields merges two edits of the same game field by field: fields only
+// the other process changed are taken from theirs, all others from ours
+func mergeFields(original []byte, ours, theirs *models.Game) *models.Game {
+	var baseFields, ourFields, theirFields map[string]json.RawMessage
+	if json.Unmarshal(original, &baseFields) != nil ||
+		unmarshalFields(ours, &ourFields) != nil || unmarshalFields(theirs, &theirFields) != nil {
+		return ours
+	}
+
+	for key, value := range theirFields {
+		if bytes.Equal(ourFields[key], baseFields[key]) {
+			ourFields[key] = value
+		}
+	}
+	// Fields left out as empty by theirs were cleared there
+	for key, value := range ourFields {
+		if _, ok := theirFields[key]; !ok && bytes.Equal(value, baseFields[key]) {
+			delete(ourFields, key)
+		}
+	}
+
+	data, err := json.Marshal(ourFields)
+	if err != nil {
+		return ours
+	}
+	merged := &models.Game{}
+	if err := json.Unmarshal(data, merged); err != nil {
+		return ours
+	}
+	return merged
+}
+
+// unmarshalFields encodes game and splits it into its JSON fields
+func unmarshalFields(game *models.Game, fields *map[string]json.RawMessage) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, fields)
+}
+
+// encodesTo reports whether game still encodes to the given JSON
+func encodesTo(game *models.Game, encoded []byte) bool {
+	current, err := json.Marshal(game)
+	return err == nil && bytes.Equal(current, encoded)
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// mergeWithDisk merges games with the library on disk if another process
+// changed it since our snapshot. Callers must hold the data lock.
+func (m *Manager) mergeWithDisk(filePath string, games []*models.Game) []*models.Game {
+	data, err := os.ReadFile(filePath)
+	if err != nil || hashBytes(data) == m.snapshot.hash {
+		return games
+	}
+
+	theirs, _, err := decodeGames(filePath, data)
+	if err != nil {
+		fmt.Printf("Warning: Not merging unreadable games file %s: %v\n", filePath, err)
+		return games
+	}
+
+	return mergeGames(m.snapshot.games, games, theirs)
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gamelauncher/models"
+)
+
+func TestMergeGames(t *testing.T) {
+	game := func(id, name, version string, tags ...string) *models.Game {
+		return &models.Game{ID: id, Name: name, Version: version, Tags: tags}
+	}
+
+	tests := []struct {
+		name   string
+		base   []*models.Game
+		ours   []*models.Game
+		theirs []*models.Game
+		want   []*models.Game
+	}{
+		{
+			name:   "nothing changed",
+			base:   []*models.Game{game("a", "Alpha", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha", "1.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.0")},
+			want:   []*models.Game{game("a", "Alpha", "1.0")},
+		},
+		{
+			name:   "only theirs edited",
+			base:   []*models.Game{game("a", "Alpha", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha", "1.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.1")},
+			want:   []*models.Game{game("a", "Alpha", "1.1")},
+		},
+		{
+			name:   "only ours edited",
+			base:   []*models.Game{game("a", "Alpha", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha Renamed", "1.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.0")},
+			want:   []*models.Game{game("a", "Alpha Renamed", "1.0")},
+		},
+		{
+			name:   "both edited different fields",
+			base:   []*models.Game{game("a", "Alpha", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha Renamed", "1.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.1")},
+			want:   []*models.Game{game("a", "Alpha Renamed", "1.1")},
+		},
+		{
+			name:   "both edited the same field",
+			base:   []*models.Game{game("a", "Alpha", "1.0")},
+			ours:   []*models.Game{game("a", "Ours", "1.0")},
+			theirs: []*models.Game{game("a", "Theirs", "1.0")},
+			want:   []*models.Game{game("a", "Ours", "1.0")},
+		},
+		{
+			name:   "theirs cleared a field ours left alone",
+			base:   []*models.Game{game("a", "Alpha", "1.0", "favorite")},
+			ours:   []*models.Game{game("a", "Alpha Renamed", "1.0", "favorite")},
+			theirs: []*models.Game{game("a", "Alpha", "1.0")},
+			want:   []*models.Game{game("a", "Alpha Renamed", "1.0")},
+		},
+		{
+			name:   "deleted by theirs, unchanged by ours",
+			base:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "1.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.0")},
+			want:   []*models.Game{game("a", "Alpha", "1.0")},
+		},
+		{
+			name:   "deleted by theirs, edited by ours",
+			base:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "2.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.0")},
+			want:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "2.0")},
+		},
+		{
+			name:   "deleted by ours, unchanged by theirs",
+			base:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha", "1.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "1.0")},
+			want:   []*models.Game{game("a", "Alpha", "1.0")},
+		},
+		{
+			name:   "deleted by ours, edited by theirs",
+			base:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha", "1.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "2.0")},
+			want:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "2.0")},
+		},
+		{
+			name:   "added on both sides",
+			base:   []*models.Game{game("a", "Alpha", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "1.0")},
+			theirs: []*models.Game{game("a", "Alpha", "1.0"), game("c", "Gamma", "1.0")},
+			want:   []*models.Game{game("a", "Alpha", "1.0"), game("b", "Beta", "1.0"), game("c", "Gamma", "1.0")},
+		},
+		{
+			name:   "everything deleted",
+			base:   []*models.Game{game("a", "Alpha", "1.0")},
+			ours:   []*models.Game{game("a", "Alpha", "1.0")},
+			theirs: nil,
+			want:   []*models.Game{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := make(map[string][]byte, len(tt.base))
+			for _, game := range tt.base {
+				encoded, err := json.Marshal(game)
+				if err != nil {
+					t.Fatal(err)
+				}
+				base[game.ID] = encoded
+			}
+
+			got := mergeGames(base, tt.ours, tt.theirs)
+			sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeGames = %s, want %s", encodeForTest(got), encodeForTest(tt.want))
+			}
+		})
+	}
+}
+
+func encodeForTest(games []*models.Game) string {
+	data, _ := json.Marshal(games)
+	return string(data)
+}
@@ -1,17 +1,22 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"gamelauncher/game"
 	"gamelauncher/models"
 	"gamelauncher/monitor"
+	"gamelauncher/saves"
 	"gamelauncher/search"
 	"gamelauncher/steam"
 	"gamelauncher/storage"
+	"gamelauncher/version"
 	"image/color"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -30,25 +35,42 @@ import (
 	"github.com/ncruces/zenity"
 )
 
+// libraryPollInterval is how often the games file is checked for changes
+// made by other launcher processes
+const libraryPollInterval = 2 * time.Second
+
 // MainWindow represents the main application window
 type MainWindow struct {
 	app           fyne.App
 	window        fyne.Window
 	gameManager   *game.Manager
 	storage       *storage.Manager
+	store         storage.GameStore // Game library backend chosen in the settings
 	monitor       *monitor.SourceMonitor
 	searchService *search.Manager
 	steamManager  *steam.Manager
+	saveManager   *saves.Manager
 	games         []*models.Game
 	gamesMutex    sync.RWMutex // Protects concurrent access to games slice
+	syncMutex     sync.Mutex   // Serializes writing and reloading the whole library
 	settings      *models.Settings
 	gameList      *widget.List
 	refreshTimer  *time.Timer
-	selectedGame  int // Track selected game index
+	selectedGame  int    // Track selected game index
+	engineFilter  string // Engine whose games are listed, all games if empty
+
+	watchMutex     sync.Mutex      // Protects the library watcher and pending imports
+	libraryWatcher *game.Watcher   // Watches the library folders, if any are set
+	pendingImports []*models.Game  // Games found in the library folders, not yet imported
+	ignoredImports map[string]bool // Folders of pending imports the user dismissed
+
+	checkMutex  sync.Mutex         // Protects the running update check
+	checkCtx    context.Context    // Context of the running update check, if any
+	checkCancel context.CancelFunc // Cancels the running update check
 }
 
-// NewMainWindow creates a new main window
-func NewMainWindow() *MainWindow {
+// NewMainWindow creates a new main window keeping its data in dataDir
+func NewMainWindow(dataDir string) *MainWindow {
 	myApp := app.New()
 	myApp.SetIcon(theme.ComputerIcon())
 
@@ -59,16 +81,23 @@ func NewMainWindow() *MainWindow {
 		app:           myApp,
 		window:        window,
 		gameManager:   game.NewManager(),
-		storage:       storage.NewManager(),
-		monitor:       monitor.NewSourceMonitor(),
-		searchService: search.NewManager(),
+		storage:       storage.NewManager(dataDir),
+		monitor:       monitor.NewSourceMonitor(dataDir),
+		searchService: search.NewManager(dataDir),
 		steamManager:  steam.NewManager(),
+		saveManager:   saves.NewManager(dataDir),
 		selectedGame:  -1, // Initialize to no selection
 	}
+	mw.ignoredImports = make(map[string]bool)
+
+	mw.gameManager.SetSessionHandler(mw.recordSession)
+	mw.gameManager.SetSaveManager(mw.saveManager)
 
 	mw.loadData()
 	mw.setupUI()
 	mw.startUpdateTimer()
+	mw.watchLibrary()
+	mw.startLibraryWatcher()
 
 	return mw
 }
@@ -82,16 +111,31 @@ func (mw *MainWindow) ShowAndRun() {
 func (mw *MainWindow) loadData() {
 	var err error
 
-	mw.games, err = mw.storage.LoadGames()
+	mw.settings, err = mw.storage.LoadSettings()
 	if err != nil {
 		dialog.ShowError(err, mw.window)
-		mw.games = []*models.Game{}
+		mw.settings = models.DefaultSettings()
 	}
+	mw.storage.SetBackupCount(mw.settings.BackupCount)
+	mw.gameManager.SetRunnerConfig(game.NewRunnerConfig(mw.settings, mw.storage.DataPath()))
+	mw.gameManager.SetScanOptions(game.NewScanOptions(mw.settings))
+	mw.saveManager.SetKeep(mw.settings.SaveSnapshots)
 
-	mw.settings, err = mw.storage.LoadSettings()
+	mw.store, err = storage.OpenGameStore(mw.storage, mw.settings.StorageBackend)
 	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to open %s storage, using games.json: %w", mw.settings.StorageBackend, err), mw.window)
+		mw.store = mw.storage
+	}
+
+	mw.games, err = mw.store.LoadGames()
+	var recovered *storage.RecoveryError
+	if errors.As(err, &recovered) {
+		dialog.ShowInformation("Library Restored",
+			fmt.Sprintf("The game library file was damaged and has been restored from the backup %s.\n\nThe damaged file was kept as:\n%s",
+				filepath.Base(recovered.BackupPath), recovered.CorruptPath), mw.window)
+	} else if err != nil {
 		dialog.ShowError(err, mw.window)
-		mw.settings = models.DefaultSettings()
+		mw.games = []*models.Game{}
 	}
 }
 
@@ -105,7 +149,7 @@ func (mw *MainWindow) setupUI() {
 		func() int {
 			mw.gamesMutex.RLock()
 			defer mw.gamesMutex.RUnlock()
-			return len(mw.games)
+			return len(mw.visibleGamesLocked())
 		},
 		func() fyne.CanvasObject {
 			// Create image and name container on the left
@@ -151,11 +195,12 @@ func (mw *MainWindow) setupUI() {
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			mw.gamesMutex.RLock()
-			if int(id) >= len(mw.games) {
+			visible := mw.visibleGamesLocked()
+			if int(id) >= len(visible) {
 				mw.gamesMutex.RUnlock()
 				return // Prevent index out of bounds
 			}
-			game := mw.games[id]
+			game := mw.games[visible[id]]
 			mw.gamesMutex.RUnlock()
 			borderContainer := obj.(*fyne.Container)
 
@@ -248,6 +293,16 @@ func (mw *MainWindow) setupUI() {
 									launchBtn.OnTapped = func() {
 										mw.launchGame(game)
 									}
+									if mw.gameManager.IsRunning(game.ID) {
+										launchBtn.SetText("Running")
+										launchBtn.Disable()
+									} else if !game.IsInstalled {
+										launchBtn.SetText("Missing")
+										launchBtn.Disable()
+									} else {
+										launchBtn.SetText("Launch")
+										launchBtn.Enable()
+									}
 								}
 							}
 						}
@@ -270,17 +325,84 @@ func (mw *MainWindow) setupUI() {
 
 	// Add selection tracking
 	mw.gameList.OnSelected = func(id widget.ListItemID) {
-		mw.selectedGame = int(id)
+		mw.gamesMutex.RLock()
+		defer mw.gamesMutex.RUnlock()
+		if visible := mw.visibleGamesLocked(); int(id) < len(visible) {
+			mw.selectedGame = visible[id]
+		}
 	}
 
-	// Create main container
-	content := container.NewBorder(toolbar, nil, nil, nil, mw.gameList)
+	// Create main container with the engine filter next to the toolbar
+	top := container.NewBorder(nil, nil, nil, mw.createEngineFilter(), toolbar)
+	content := container.NewBorder(top, nil, nil, nil, mw.gameList)
 	mw.window.SetContent(content)
+	mw.window.SetMainMenu(mw.createMainMenu())
 
 	// Start version checking for all games
 	mw.refreshAllVersionChecks()
 }
 
+// engineFilterAll and engineFilterUnknown are the engine filter choices
+// that are not an engine
+const (
+	engineFilterAll     = "All Engines"
+	engineFilterUnknown = "Unknown Engine"
+)
+
+// createEngineFilter creates the selector limiting the list to one engine
+func (mw *MainWindow) createEngineFilter() *widget.Select {
+	options := append([]string{engineFilterAll}, engineLabels()...)
+
+	engineSelect := widget.NewSelect(options, func(selected string) {
+		mw.gamesMutex.Lock()
+		switch selected {
+		case engineFilterAll:
+			mw.engineFilter = ""
+		case engineFilterUnknown:
+			mw.engineFilter = engineFilterUnknown
+		default:
+			mw.engineFilter = selected
+		}
+		// The selected game may no longer be listed
+		mw.selectedGame = -1
+		mw.gamesMutex.Unlock()
+
+		if mw.gameList != nil {
+			mw.gameList.UnselectAll()
+			mw.gameList.Refresh()
+		}
+	})
+	engineSelect.SetSelected(engineFilterAll)
+	return engineSelect
+}
+
+// engineLabels returns the engines a game can be set to, ending with the
+// choice for an unknown engine
+func engineLabels() []string {
+	return append(game.Engines(), engineFilterUnknown)
+}
+
+// visibleGamesLocked returns the indexes into games of the games listed with
+// the current engine filter. Callers must hold gamesMutex.
+func (mw *MainWindow) visibleGamesLocked() []int {
+	visible := make([]int, 0, len(mw.games))
+	for i, g := range mw.games {
+		switch mw.engineFilter {
+		case "":
+		case engineFilterUnknown:
+			if g.Engine != "" {
+				continue
+			}
+		default:
+			if g.Engine != mw.engineFilter {
+				continue
+			}
+		}
+		visible = append(visible, i)
+	}
+	return visible
+}
+
 // createToolbar creates the main toolbar
 func (mw *MainWindow) createToolbar() *widget.Toolbar {
 	return widget.NewToolbar(
@@ -303,6 +425,9 @@ func (mw *MainWindow) createToolbar() *widget.Toolbar {
 		widget.NewToolbarAction(theme.DownloadIcon(), func() {
 			mw.fetchImagesForAllGames()
 		}),
+		widget.NewToolbarAction(theme.HistoryIcon(), func() {
+			mw.showVersionHistory()
+		}),
 		widget.NewToolbarSeparator(),
 		widget.NewToolbarAction(theme.ComputerIcon(), func() {
 			mw.addSelectedGameToSteam()
@@ -317,6 +442,183 @@ func (mw *MainWindow) createToolbar() *widget.Toolbar {
 	)
 }
 
+// createMainMenu creates the window menu
+func (mw *MainWindow) createMainMenu() *fyne.MainMenu {
+	return fyne.NewMainMenu(
+		fyne.NewMenu("Library",
+			fyne.NewMenuItem("Export Library...", mw.exportLibrary),
+			fyne.NewMenuItem("Import Library...", mw.importLibrary),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Detect Installed Versions", mw.detectInstalledVersions),
+			fyne.NewMenuItem("Pending Imports...", mw.showPendingImports),
+			fyne.NewMenuItem("Disk Usage...", mw.showDiskUsage),
+		),
+		fyne.NewMenu("Game",
+			fyne.NewMenuItem("Install Update...", mw.installUpdate),
+			fyne.NewMenuItem("Switch Installed Version...", mw.switchInstalledVersion),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Save Backups...", mw.showSaveBackups),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Uninstall...", mw.uninstallSelectedGame),
+		),
+	)
+}
+
+// exportLibrary saves the library as a .zip bundle, .csv file or Markdown
+// report, chosen by the file extension
+func (mw *MainWindow) exportLibrary() {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+			return
+		}
+		filePath := writer.URI().Path()
+		writer.Close()
+
+		if _, err := storage.FormatFromPath(filePath); err != nil {
+			os.Remove(filePath)
+			dialog.ShowError(err, mw.window)
+			return
+		}
+
+		games := mw.copyGames()
+		if err := mw.storage.Export(filePath, games, mw.settings); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to export library: %w", err), mw.window)
+			return
+		}
+		mw.saveLastUsedPath(filePath)
+		dialog.ShowInformation("Export Complete",
+			fmt.Sprintf("Exported %d games to:\n%s", len(games), filePath), mw.window)
+	}, mw.window)
+
+	saveDialog.SetFileName("gamelauncher-library.zip")
+	saveDialog.SetFilter(fynestorage.NewExtensionFileFilter([]string{".zip", ".csv", ".md"}))
+	if startLocation := mw.getLastUsedPath(); startLocation != "" {
+		if listable, ok := fynestorage.NewFileURI(startLocation).(fyne.ListableURI); ok {
+			saveDialog.SetLocation(listable)
+		}
+	}
+	saveDialog.Show()
+}
+
+// importLibrary adds the games of a .zip bundle, .csv file or games.json,
+// asking how paths from the other machine map to this one
+func (mw *MainWindow) importLibrary() {
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+			return
+		}
+		filePath := reader.URI().Path()
+		reader.Close()
+		mw.saveLastUsedPath(filePath)
+		mw.showImportOptions(filePath)
+	}, mw.window)
+
+	openDialog.SetFilter(fynestorage.NewExtensionFileFilter([]string{".zip", ".csv", ".json"}))
+	if startLocation := mw.getLastUsedPath(); startLocation != "" {
+		if listable, ok := fynestorage.NewFileURI(startLocation).(fyne.ListableURI); ok {
+			openDialog.SetLocation(listable)
+		}
+	}
+	openDialog.Show()
+}
+
+// detectInstalledVersions sets the current version of every game from its
+// local files, for games updated outside the launcher
+func (mw *MainWindow) detectInstalledVersions() {
+	var updated []string
+	for _, g := range mw.copyGames() {
+		if mw.gameManager.DetectInstalledVersion(g) {
+			updated = append(updated, fmt.Sprintf("%s: %s", g.Name, g.CurrentVersion))
+		}
+	}
+
+	if len(updated) == 0 {
+		dialog.ShowInformation("Installed Versions", "No new installed versions were found.", mw.window)
+		return
+	}
+	mw.saveGames()
+	mw.gameList.Refresh()
+	dialog.ShowInformation("Installed Versions",
+		fmt.Sprintf("Updated %d games:\n\n%s", len(updated), strings.Join(updated, "\n")), mw.window)
+}
+
+// showImportOptions asks for path mappings, then imports filePath
+func (mw *MainWindow) showImportOptions(filePath string) {
+	mappingsEntry := widget.NewMultiLineEntry()
+	mappingsEntry.SetPlaceHolder("One per line, e.g. D:\\Games=/mnt/games")
+
+	settingsCheck := widget.NewCheck("Also import settings (bundles only)", nil)
+
+	form := dialog.NewForm("Import Library", "Import", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("File", widget.NewLabel(filepath.Base(filePath))),
+			widget.NewFormItem("Path Mappings", mappingsEntry),
+			widget.NewFormItem("", settingsCheck),
+		},
+		func(confirm bool) {
+			if !confirm {
+				return
+			}
+
+			opts := storage.ImportOptions{Settings: settingsCheck.Checked}
+			for _, line := range strings.Split(mappingsEntry.Text, "\n") {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				mapping, err := storage.ParsePathMapping(line)
+				if err != nil {
+					dialog.ShowError(err, mw.window)
+					return
+				}
+				opts.PathMappings = append(opts.PathMappings, mapping)
+			}
+
+			result, err := mw.storage.Import(filePath, mw.copyGames(), opts)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("failed to import library: %w", err), mw.window)
+				return
+			}
+
+			if len(result.Games) > 0 {
+				mw.gamesMutex.Lock()
+				mw.games = append(mw.games, result.Games...)
+				mw.gamesMutex.Unlock()
+				mw.saveGames()
+				mw.gameList.Refresh()
+			}
+
+			if result.Settings != nil {
+				// The backend describes this data directory, not the exported one
+				result.Settings.StorageBackend = mw.settings.StorageBackend
+				mw.settings = result.Settings
+				mw.storage.SetBackupCount(mw.settings.BackupCount)
+				mw.saveSettings()
+				mw.restartUpdateTimer()
+			}
+
+			message := fmt.Sprintf("Imported %d new games.", len(result.Games))
+			if len(result.Duplicates) > 0 {
+				names := make([]string, 0, len(result.Duplicates))
+				for _, duplicate := range result.Duplicates {
+					names = append(names, duplicate.Name)
+				}
+				message += fmt.Sprintf("\n\nSkipped %d already in the library:\n%s",
+					len(result.Duplicates), mw.truncateText(strings.Join(names, ", "), 300))
+			}
+			dialog.ShowInformation("Import Complete", message, mw.window)
+		},
+		mw.window)
+
+	form.Resize(fyne.NewSize(450, 300))
+	form.Show()
+}
+
 // getLastUsedPath returns the last used path or user's home directory
 func (mw *MainWindow) getLastUsedPath() string {
 	if mw.settings.LastUsedPath != "" {
@@ -363,8 +665,8 @@ func (mw *MainWindow) openNativeFileDialog() (string, error) {
 			zenity.Title("Select Executable"),
 			zenity.Filename(startPath),
 			zenity.FileFilters{
-				{"Executable files", []string{"*.exe", "*.sh", "*.run", "*.AppImage"}, false},
-				{"All files", []string{"*"}, false},
+				{Name: "Executable files", Patterns: []string{"*.exe", "*.sh", "*.run", "*.AppImage"}},
+				{Name: "All files", Patterns: []string{"*"}},
 			},
 		)
 
@@ -520,6 +822,14 @@ func (mw *MainWindow) importGames() {
 					// Game with same name exists, update the executable path instead of adding duplicate
 					existingGame.Executable = newGame.Executable
 					existingGame.Folder = newGame.Folder
+					existingGame.WorkingDir = newGame.WorkingDir
+					existingGame.AltExecutables = newGame.AltExecutables
+					if newGame.Engine != "" {
+						existingGame.Engine = newGame.Engine
+					}
+					if newGame.CurrentVersion != "" {
+						existingGame.CurrentVersion = newGame.CurrentVersion
+					}
 					exists = true
 					break
 				}
@@ -613,7 +923,7 @@ func (mw *MainWindow) addGame() {
 			mw.games = append(mw.games, newGame)
 			mw.gamesMutex.Unlock()
 
-			mw.saveGames()
+			mw.saveGame(newGame)
 			mw.gameList.Refresh()
 		},
 		mw.window)
@@ -805,11 +1115,37 @@ func (mw *MainWindow) launchGame(game *models.Game) {
 	if err != nil {
 		dialog.ShowError(err, mw.window)
 	} else {
+		mw.gameList.Refresh()
 		dialog.ShowInformation("Game Launched",
 			fmt.Sprintf("Launched %s successfully!", game.Name), mw.window)
 	}
 }
 
+// recordSession stores the session of a game that exited and adds it to the
+// game's playtime. It is called in the background by the game manager.
+func (mw *MainWindow) recordSession(played *models.Game, session models.Session) {
+	if err := mw.storage.RecordSession(played.ID, session); err != nil {
+		fmt.Printf("Warning: Failed to record play session for %s: %v\n", played.Name, err)
+	}
+
+	// The list may have been reloaded while playing, so look the game up again
+	var current *models.Game
+	mw.gamesMutex.Lock()
+	for _, game := range mw.games {
+		if game.ID == played.ID {
+			current = game
+			current.AddSession(session)
+			break
+		}
+	}
+	mw.gamesMutex.Unlock()
+
+	if current != nil {
+		mw.saveGame(current)
+	}
+	mw.gameList.Refresh()
+}
+
 // editGame shows a dialog to edit game properties
 func (mw *MainWindow) editGame(game *models.Game) {
 	nameEntry := widget.NewEntry()
@@ -834,12 +1170,32 @@ func (mw *MainWindow) editGame(game *models.Game) {
 	// Create executable selection container
 	execContainer := container.NewBorder(nil, nil, nil, browseBtn, execEntry)
 
+	// Other programs found when the game was scanned, e.g. a 32-bit build
+	altSelect := widget.NewSelect(game.AltExecutables, func(selected string) {
+		execEntry.SetText(selected)
+	})
+	altSelect.PlaceHolder = "Other executables in the game folder"
+	if len(game.AltExecutables) == 0 {
+		altSelect.Disable()
+	}
+
 	urlEntry := widget.NewEntry()
 	urlEntry.SetText(game.SourceURL)
 
 	descEntry := widget.NewMultiLineEntry()
 	descEntry.SetText(game.Description)
 
+	tagsEntry := widget.NewEntry()
+	tagsEntry.SetText(strings.Join(game.Tags, ", "))
+	tagsEntry.SetPlaceHolder("e.g., favorite, finished")
+
+	engineSelect := widget.NewSelect(engineLabels(), nil)
+	if game.Engine != "" {
+		engineSelect.SetSelected(game.Engine)
+	} else {
+		engineSelect.SetSelected(engineFilterUnknown)
+	}
+
 	// Version checking configuration
 	versionSelectorEntry := widget.NewEntry()
 	versionSelectorEntry.SetText(game.VersionSelector)
@@ -853,15 +1209,74 @@ func (mw *MainWindow) editGame(game *models.Game) {
 	currentVersionEntry.SetText(game.CurrentVersion)
 	currentVersionEntry.SetPlaceHolder("Current version for comparison")
 
+	prereleaseCheck := widget.NewCheck("Include prereleases (GitHub)", nil)
+	prereleaseCheck.SetChecked(game.IncludePrereleases)
+
+	// Launch configuration
+	argsEntry := widget.NewEntry()
+	argsEntry.SetText(joinLaunchArgs(game.LaunchArgs))
+	argsEntry.SetPlaceHolder("e.g., -windowed --lang=en")
+	argsEntry.Validator = validateLaunchArgs
+
+	envEntry := widget.NewMultiLineEntry()
+	envEntry.SetText(strings.Join(game.LaunchEnv, "\n"))
+	envEntry.SetPlaceHolder("One per line, e.g., LANG=en_US.UTF-8")
+	envEntry.Validator = validateLaunchEnv
+
+	workingDirEntry := widget.NewEntry()
+	workingDirEntry.SetText(game.WorkingDir)
+	workingDirEntry.SetPlaceHolder("Defaults to the game folder")
+
+	runnerSelect := widget.NewSelect(runnerLabels(game.Executable), nil)
+	runnerSelect.SetSelected(runnerLabel(game.Runner, game.Executable))
+
+	prefixEntry := widget.NewEntry()
+	prefixEntry.SetText(game.WinePrefix)
+	prefixEntry.SetPlaceHolder("Wine: ~/.wine, Proton: one per game")
+
+	wrapperEntry := widget.NewEntry()
+	wrapperEntry.SetText(joinLaunchArgs(game.Wrapper))
+	wrapperEntry.SetPlaceHolder("e.g., gamemoderun mangohud")
+	wrapperEntry.Validator = validateLaunchArgs
+
+	saveDirsEntry := widget.NewMultiLineEntry()
+	saveDirsEntry.SetText(strings.Join(game.SaveDirs, "\n"))
+	if detected := saves.DetectLocations(game); len(detected) > 0 {
+		saveDirsEntry.SetPlaceHolder(strings.Join(detected, "\n"))
+	} else {
+		saveDirsEntry.SetPlaceHolder("One folder per line")
+	}
+
+	playtimeText := "Never played"
+	if !game.LastPlayed.IsZero() {
+		playtimeText = fmt.Sprintf("%s, last played %s", models.FormatPlaytime(game.Playtime()),
+			game.LastPlayed.Format("2006-01-02 15:04"))
+	}
+	if mw.gameManager.IsRunning(game.ID) {
+		playtimeText += " (running)"
+	}
+
 	form := dialog.NewForm("Edit Game", "Save", "Cancel",
 		[]*widget.FormItem{
+			widget.NewFormItem("Playtime", widget.NewLabel(playtimeText)),
 			widget.NewFormItem("Name", nameEntry),
 			widget.NewFormItem("Executable", execContainer),
+			widget.NewFormItem("Alternatives", altSelect),
 			widget.NewFormItem("Source URL", urlEntry),
 			widget.NewFormItem("Description", descEntry),
+			widget.NewFormItem("Tags", tagsEntry),
+			widget.NewFormItem("Engine", engineSelect),
+			widget.NewFormItem("Launch Arguments", argsEntry),
+			widget.NewFormItem("Environment", envEntry),
+			widget.NewFormItem("Working Directory", workingDirEntry),
+			widget.NewFormItem("Runner", runnerSelect),
+			widget.NewFormItem("Wine/Proton Prefix", prefixEntry),
+			widget.NewFormItem("Wrapper Command", wrapperEntry),
+			widget.NewFormItem("Save Folders", saveDirsEntry),
 			widget.NewFormItem("Version Selector (CSS)", versionSelectorEntry),
 			widget.NewFormItem("Version Pattern (Regex)", versionPatternEntry),
 			widget.NewFormItem("Current Version", currentVersionEntry),
+			widget.NewFormItem("", prereleaseCheck),
 		},
 		func(confirm bool) {
 			if !confirm {
@@ -871,13 +1286,36 @@ func (mw *MainWindow) editGame(game *models.Game) {
 			// Store original source URL to detect changes
 			originalSourceURL := game.SourceURL
 
+			// Forget the fetched version when the check configuration changes so the
+			// next check parses the source again instead of trusting the HTTP cache
+			if urlEntry.Text != game.SourceURL ||
+				versionSelectorEntry.Text != game.VersionSelector ||
+				versionPatternEntry.Text != game.VersionPattern ||
+				prereleaseCheck.Checked != game.IncludePrereleases {
+				game.Version = ""
+			}
+
 			game.Name = nameEntry.Text
+			game.AltExecutables = swapAlternate(game.AltExecutables, game.Executable, execEntry.Text)
 			game.Executable = execEntry.Text
 			game.SourceURL = urlEntry.Text
 			game.Description = descEntry.Text
+			game.Tags = parseTags(tagsEntry.Text)
+			game.Engine = engineSelect.Selected
+			if game.Engine == engineFilterUnknown {
+				game.Engine = ""
+			}
+			game.LaunchArgs, _ = parseLaunchArgs(argsEntry.Text)
+			game.LaunchEnv, _ = parseLaunchEnv(envEntry.Text)
+			game.WorkingDir = strings.TrimSpace(workingDirEntry.Text)
+			game.Runner = runnerFromLabel(runnerSelect.Selected)
+			game.WinePrefix = strings.TrimSpace(prefixEntry.Text)
+			game.Wrapper, _ = parseLaunchArgs(wrapperEntry.Text)
+			game.SaveDirs = parseLines(saveDirsEntry.Text)
 			game.VersionSelector = versionSelectorEntry.Text
 			game.VersionPattern = versionPatternEntry.Text
 			game.CurrentVersion = currentVersionEntry.Text
+			game.IncludePrereleases = prereleaseCheck.Checked
 
 			// If source URL changed, re-download image from the new source
 			if originalSourceURL != game.SourceURL && game.SourceURL != "" {
@@ -893,14 +1331,14 @@ func (mw *MainWindow) editGame(game *models.Game) {
 					} else {
 						// Update game with new image path
 						game.ImagePath = imagePath
-						mw.saveGames()
+						mw.saveGame(game)
 						mw.gameList.Refresh()
 						fmt.Printf("DEBUG: Successfully downloaded image from source URL: %s\n", imagePath)
 					}
 				}()
 			}
 
-			mw.saveGames()
+			mw.saveGame(game)
 			mw.gameList.Refresh()
 		},
 		mw.window)
@@ -909,7 +1347,127 @@ func (mw *MainWindow) editGame(game *models.Game) {
 	form.Show()
 }
 
-// deleteSelectedGame deletes the currently selected game
+// The editGame parameter shadows the game package, so the launch field
+// helpers live here
+
+// joinLaunchArgs formats launch arguments for editing
+func joinLaunchArgs(args []string) string {
+	return game.JoinArgs(args)
+}
+
+// parseLaunchArgs splits the launch arguments typed by the user
+func parseLaunchArgs(text string) ([]string, error) {
+	return game.SplitArgs(text)
+}
+
+// runnerNames maps runners to the labels shown in the edit dialog
+var runnerNames = map[string]string{
+	game.RunnerNative: "Native",
+	game.RunnerWine:   "Wine",
+	game.RunnerProton: "Proton",
+}
+
+// runnerLabels returns the runner choices for a game, starting with the
+// automatic choice for its executable
+func runnerLabels(executable string) []string {
+	labels := []string{runnerLabel(game.RunnerAuto, executable)}
+	for _, name := range game.Runners() {
+		labels = append(labels, runnerNames[name])
+	}
+	return labels
+}
+
+// runnerLabel returns the label of a runner; the automatic choice names the
+// runner it resolves to
+func runnerLabel(runner, executable string) string {
+	if runner == game.RunnerAuto {
+		resolved := game.ResolveRunner(&models.Game{Executable: executable})
+		return fmt.Sprintf("Automatic (%s)", runnerNames[resolved])
+	}
+	if label, ok := runnerNames[runner]; ok {
+		return label
+	}
+	return runner
+}
+
+// runnerFromLabel returns the runner selected by a label
+func runnerFromLabel(label string) string {
+	for name, l := range runnerNames {
+		if l == label {
+			return name
+		}
+	}
+	return game.RunnerAuto
+}
+
+// parseLaunchEnv parses KEY=VALUE lines typed by the user
+func parseLaunchEnv(text string) ([]string, error) {
+	return game.ParseEnv(text)
+}
+
+// validateLaunchArgs reports whether text splits into launch arguments
+func validateLaunchArgs(text string) error {
+	_, err := parseLaunchArgs(text)
+	return err
+}
+
+// validateLaunchEnv reports whether text holds KEY=VALUE lines
+func validateLaunchEnv(text string) error {
+	_, err := parseLaunchEnv(text)
+	return err
+}
+
+// parseLines splits text into its non-blank lines
+func parseLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// swapAlternate returns the alternate executables after switching the game
+// from executable old to new: new leaves the list and old takes its place
+func swapAlternate(alternates []string, old, new string) []string {
+	if old == new {
+		return alternates
+	}
+	for i, alt := range alternates {
+		if alt == new {
+			swapped := append([]string(nil), alternates...)
+			swapped[i] = old
+			return swapped
+		}
+	}
+	return alternates
+}
+
+// parseTags splits a comma separated tag list, dropping blanks and duplicates
+func parseTags(text string) []string {
+	var tags []string
+	for _, tag := range strings.Split(text, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		duplicate := false
+		for _, existing := range tags {
+			if strings.EqualFold(existing, tag) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// deleteSelectedGame removes the currently selected game from the library,
+// leaving its files on disk
 func (mw *MainWindow) deleteSelectedGame() {
 	mw.gamesMutex.RLock()
 	if mw.selectedGame < 0 || mw.selectedGame >= len(mw.games) {
@@ -924,201 +1482,762 @@ func (mw *MainWindow) deleteSelectedGame() {
 
 	// Show confirmation dialog
 	dialog.ShowConfirm("Delete Game",
-		fmt.Sprintf("Are you sure you want to delete '%s'?\n\nThis action cannot be undone.", game.Name),
+		fmt.Sprintf("Are you sure you want to delete '%s'?\n\nThis action cannot be undone. The game's files are kept; use \"Game → Uninstall\" to remove them.", game.Name),
 		func(confirm bool) {
 			if !confirm {
 				return
 			}
-
-			// Remove game from slice
-			mw.gamesMutex.Lock()
-			mw.games = append(mw.games[:mw.selectedGame], mw.games[mw.selectedGame+1:]...)
-			mw.gamesMutex.Unlock()
-
-			// Reset selection
-			mw.selectedGame = -1
-
-			// Save changes
-			mw.saveGames()
-
-			// Refresh the list
-			mw.gameList.Refresh()
+			mw.removeFromLibrary(game)
 
 			dialog.ShowInformation("Game Deleted",
 				fmt.Sprintf("'%s' has been deleted successfully.", game.Name), mw.window)
 		}, mw.window)
 }
 
-// updateFetchedVersionLabel updates the fetched version label with cached information only
-func (mw *MainWindow) updateFetchedVersionLabel(game *models.Game, label *ColoredLabel) {
-	// If no source URL, show as unavailable
-	if game.SourceURL == "" {
-		label.SetText("No source")
-		return
+// removeFromLibrary drops a game and its version history and play sessions
+func (mw *MainWindow) removeFromLibrary(removed *models.Game) {
+	mw.gamesMutex.Lock()
+	for i, g := range mw.games {
+		if g.ID == removed.ID {
+			mw.games = append(mw.games[:i], mw.games[i+1:]...)
+			break
+		}
 	}
+	mw.gamesMutex.Unlock()
 
-	// Check if we have cached version information
-	if game.Version != "" {
-		// Display cached version information
-		var displayText string
-		var bgColor, textColor color.Color
-
-		if game.Version == game.CurrentVersion {
-			// Same version - show with green background
-			displayText = game.Version
-			bgColor = color.NRGBA{R: 0, G: 255, B: 0, A: 100} // Light green
-			textColor = color.Black
-		} else if game.Version != "" && game.Version != game.CurrentVersion {
-			// Different version - determine if it's newer
-			if mw.isVersionNewer(game.Version, game.CurrentVersion) {
-				// Newer version available - show with red background
-				displayText = game.Version + " [NEW]"
-				bgColor = color.NRGBA{R: 255, G: 0, B: 0, A: 100} // Light red
-				textColor = color.White
-			} else {
-				// Different version but not newer - show with orange background
-				displayText = game.Version + " [DIFF]"
-				bgColor = color.NRGBA{R: 255, G: 165, B: 0, A: 100} // Light orange
-				textColor = color.Black
-			}
-		} else {
-			// No cached version
-			displayText = "Not checked"
-			bgColor = color.NRGBA{R: 128, G: 128, B: 128, A: 100} // Light gray
-			textColor = color.Black
-		}
+	// Reset selection
+	mw.selectedGame = -1
 
-		// Update the label only if something changed to avoid unnecessary refreshes
-		newText := mw.truncateText(displayText, 20)
-		if label.text != newText || label.bgColor != bgColor || label.textColor != textColor {
-			label.SetText(newText)
-			label.SetColors(bgColor, textColor)
-		}
-	} else {
-		// No cached version - show as not checked
-		// Only update if required
-		if label.text != "Not checked" || label.bgColor != (color.NRGBA{R: 128, G: 128, B: 128, A: 100}) {
-			label.SetText("Not checked")
-			label.SetColors(color.NRGBA{R: 128, G: 128, B: 128, A: 100}, color.Black)
-		}
+	if err := mw.storage.DeleteVersionHistory(removed.ID); err != nil {
+		fmt.Printf("Warning: Failed to delete version history for %s: %v\n", removed.Name, err)
 	}
-}
-
-// truncateText truncates text to the specified length and adds ellipsis if needed
-func (mw *MainWindow) truncateText(text string, maxLength int) string {
-	if len(text) <= maxLength {
-		return text
+	if err := mw.storage.DeleteSessions(removed.ID); err != nil {
+		fmt.Printf("Warning: Failed to delete play sessions for %s: %v\n", removed.Name, err)
 	}
-	return text[:maxLength-3] + "..."
-}
 
-// isVersionNewer compares two version strings and returns true if version1 is newer than version2
-func (mw *MainWindow) isVersionNewer(version1, version2 string) bool {
-	// Clean up version strings
-	v1 := strings.TrimSpace(version1)
-	v2 := strings.TrimSpace(version2)
-
-	// If either version is empty, can't compare
-	if v1 == "" || v2 == "" {
-		return false
+	// Save changes
+	if err := mw.store.DeleteGame(removed.ID); err != nil {
+		dialog.ShowError(err, mw.window)
 	}
 
-	// If versions are identical, neither is newer
-	if v1 == v2 {
-		return false
+	// Refresh the list
+	mw.gameList.UnselectAll()
+	mw.gameList.Refresh()
+}
+
+// uninstallSelectedGame removes the selected game's files from disk
+func (mw *MainWindow) uninstallSelectedGame() {
+	mw.gamesMutex.RLock()
+	if mw.selectedGame < 0 || mw.selectedGame >= len(mw.games) {
+		mw.gamesMutex.RUnlock()
+		dialog.ShowInformation("No Game Selected",
+			"Please select a game to uninstall.", mw.window)
+		return
+	}
+	selected := mw.games[mw.selectedGame]
+	mw.gamesMutex.RUnlock()
+
+	mw.uninstallGame(selected)
+}
+
+// uninstallGame asks how to uninstall a game, listing the folders that will
+// be removed, then removes them and the game from the library
+func (mw *MainWindow) uninstallGame(selected *models.Game) {
+	if mw.gameManager.IsRunning(selected.ID) {
+		dialog.ShowInformation("Game Running", "Close the game before uninstalling it.", mw.window)
+		return
+	}
+
+	sizeLabel := widget.NewLabel("Calculating...")
+	go func() {
+		size, err := mw.gameManager.DiskUsage(selected, false)
+		if err != nil {
+			fmt.Printf("Warning: Failed to measure %s: %v\n", selected.Name, err)
+		}
+		sizeLabel.SetText(models.FormatSize(size))
+	}()
+
+	folders := mw.gameManager.UninstallFolders(selected)
+	foldersText := "No folders found"
+	if len(folders) > 0 {
+		foldersText = strings.Join(folders, "\n")
+	}
+	foldersLabel := widget.NewLabel(foldersText)
+	foldersLabel.Wrapping = fyne.TextWrapWord
+
+	trashCheck := widget.NewCheck("Move to the trash instead of deleting", nil)
+	trashCheck.SetChecked(true)
+
+	// Save folders outside the game are mostly guessed from the engine, so
+	// they are listed on their own and only removed when ticked
+	saveFolders := mw.gameManager.ExternalSaveFolders(selected)
+	saveFoldersLabel := widget.NewLabel(strings.Join(saveFolders, "\n"))
+	saveFoldersLabel.Wrapping = fyne.TextWrapWord
+	saveFoldersCheck := widget.NewCheck("Also remove these save folders outside the game folder:", nil)
+	keepSavesCheck := widget.NewCheck("Keep saves and the game in the library, as not installed", func(keep bool) {
+		if keep {
+			saveFoldersCheck.SetChecked(false)
+			saveFoldersCheck.Disable()
+		} else {
+			saveFoldersCheck.Enable()
+		}
+	})
+	keepSavesCheck.SetChecked(true)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Game", widget.NewLabel(selected.Name)),
+		widget.NewFormItem("Size", sizeLabel),
+		widget.NewFormItem("Removes", foldersLabel),
+		widget.NewFormItem("", trashCheck),
+		widget.NewFormItem("", keepSavesCheck),
+	}
+	if len(saveFolders) > 0 {
+		items = append(items,
+			widget.NewFormItem("", saveFoldersCheck),
+			widget.NewFormItem("Save folders", saveFoldersLabel))
+	}
+
+	form := dialog.NewForm("Uninstall Game", "Uninstall", "Cancel", items,
+		func(confirm bool) {
+			if !confirm {
+				return
+			}
+			opts := game.UninstallOptions{Trash: trashCheck.Checked, KeepSaves: keepSavesCheck.Checked}
+			if !opts.KeepSaves && saveFoldersCheck.Checked {
+				opts.SaveFolders = saveFolders
+			}
+			if opts.Trash {
+				mw.runUninstall(selected, opts)
+				return
+			}
+			dialog.ShowConfirm("Delete Permanently",
+				fmt.Sprintf("Permanently delete the files of '%s'?\n\nThis action cannot be undone.", selected.Name),
+				func(confirm bool) {
+					if confirm {
+						mw.runUninstall(selected, opts)
+					}
+				}, mw.window)
+		},
+		mw.window)
+
+	form.Resize(fyne.NewSize(550, 350))
+	form.Show()
+}
+
+// runUninstall removes the game's files and Steam shortcut in the
+// background. Unless saves are kept, the game itself and its downloaded
+// image are removed too. If the trash can't be used it offers to delete the
+// files instead.
+func (mw *MainWindow) runUninstall(selected *models.Game, opts game.UninstallOptions) {
+	progress := dialog.NewCustomWithoutButtons("Uninstalling",
+		container.NewVBox(widget.NewLabel(fmt.Sprintf("Removing %s...", selected.Name)), widget.NewProgressBarInfinite()),
+		mw.window)
+	progress.Show()
+
+	go func() {
+		err := mw.gameManager.Uninstall(selected, opts)
+		progress.Hide()
+
+		if errors.Is(err, game.ErrTrashUnavailable) {
+			dialog.ShowConfirm("Trash Unavailable",
+				fmt.Sprintf("%v\n\nDelete the remaining files of '%s' permanently instead?", err, selected.Name),
+				func(confirm bool) {
+					if confirm {
+						opts.Trash = false
+						mw.runUninstall(selected, opts)
+					}
+				}, mw.window)
+			return
+		}
+		if err != nil {
+			// Some files could not be removed; the game stays listed as not
+			// installed so they can be found again
+			mw.saveGame(selected)
+			mw.gameList.Refresh()
+			dialog.ShowError(err, mw.window)
+			return
+		}
+
+		steamNote := ""
+		if exists, err := mw.steamManager.CheckGameExistsInSteam(selected); err == nil && exists {
+			if _, err := mw.steamManager.RemoveGameFromSteam(selected); err != nil {
+				fmt.Printf("Warning: Failed to remove Steam shortcut of %s: %v\n", selected.Name, err)
+			} else {
+				steamNote = "\n\nThe Steam shortcut was removed; restart Steam to see the change."
+			}
+		}
+
+		// With saves kept the game stays listed, so its save backups can be
+		// restored once it is reinstalled or found in a library folder again
+		if opts.KeepSaves {
+			mw.saveGame(selected)
+			mw.gameList.Refresh()
+			dialog.ShowInformation("Game Uninstalled",
+				fmt.Sprintf("'%s' has been uninstalled and stays in the library as not installed.\n\nIts saves are kept under \"Game → Save Backups\". After reinstalling, point \"Edit\" at the new executable, or let a library folder find it, to restore them.%s",
+					selected.Name, steamNote), mw.window)
+			return
+		}
+
+		if err := mw.storage.DeleteImage(selected, mw.copyGames()); err != nil {
+			fmt.Printf("Warning: Failed to delete image of %s: %v\n", selected.Name, err)
+		}
+		mw.removeFromLibrary(selected)
+
+		dialog.ShowInformation("Game Uninstalled",
+			fmt.Sprintf("'%s' has been uninstalled.%s", selected.Name, steamNote), mw.window)
+	}()
+}
+
+// diskUsageOrders labels the orders of the disk usage report
+var diskUsageOrders = map[string]string{
+	"Largest first": game.UsageBySize,
+	"Name":          game.UsageByName,
+}
+
+// showDiskUsage lists the disk space taken by every game, largest first or
+// by name. Sizes are measured in the background and cached on the games.
+func (mw *MainWindow) showDiskUsage() {
+	var (
+		mu     sync.Mutex
+		sorted []*models.Game
+		order  = game.UsageBySize
+	)
+
+	totalLabel := widget.NewLabel("Calculating...")
+	usageList := widget.NewList(
+		func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(sorted)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Game")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			mu.Lock()
+			defer mu.Unlock()
+			if id >= len(sorted) {
+				return
+			}
+			g := sorted[id]
+			size := models.FormatSize(g.DiskUsage)
+			if !g.IsInstalled && g.DiskUsage == 0 {
+				size = "Not installed"
+			}
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s  -  %s", g.Name, size))
+		},
+	)
+	chosen := -1
+	usageList.OnSelected = func(id widget.ListItemID) { chosen = int(id) }
+
+	show := func() {
+		games := mw.copyGames()
+		mu.Lock()
+		sorted = game.SortByDiskUsage(games, order)
+		mu.Unlock()
+		totalLabel.SetText(fmt.Sprintf("%d games, %s in total", len(games), models.FormatSize(game.TotalDiskUsage(games))))
+		chosen = -1
+		usageList.UnselectAll()
+		usageList.Refresh()
+	}
+
+	measure := func(refresh bool) {
+		totalLabel.SetText("Calculating...")
+		go func() {
+			changed, err := mw.gameManager.UpdateDiskUsage(mw.copyGames(), refresh)
+			if err != nil {
+				fmt.Printf("Warning: Failed to measure some games: %v\n", err)
+			}
+			if changed {
+				mw.saveGames()
+			}
+			show()
+		}()
+	}
+
+	var orderLabels []string
+	for label := range diskUsageOrders {
+		orderLabels = append(orderLabels, label)
+	}
+	sort.Strings(orderLabels)
+	orderSelect := widget.NewSelect(orderLabels, func(label string) {
+		order = diskUsageOrders[label]
+		show()
+	})
+	orderSelect.SetSelected("Largest first")
+
+	refreshBtn := widget.NewButton("Measure Again", func() { measure(true) })
+
+	var usageDialog dialog.Dialog
+	uninstallBtn := widget.NewButton("Uninstall...", func() {
+		mu.Lock()
+		if chosen < 0 || chosen >= len(sorted) {
+			mu.Unlock()
+			return
+		}
+		selected := sorted[chosen]
+		mu.Unlock()
+		usageDialog.Hide()
+		mw.uninstallGame(selected)
+	})
+
+	content := container.NewBorder(
+		container.NewBorder(nil, nil, widget.NewLabel("Sort by"), nil, orderSelect),
+		container.NewBorder(nil, nil, totalLabel, container.NewHBox(refreshBtn, uninstallBtn)),
+		nil, nil,
+		usageList,
+	)
+
+	usageDialog = dialog.NewCustom("Disk Usage", "Close", content, mw.window)
+	usageDialog.Resize(fyne.NewSize(600, 500))
+	usageDialog.Show()
+	measure(false)
+}
+
+// installUpdate installs a downloaded archive as the new version of the
+// selected game, offering the matching archives in the downloads folder
+func (mw *MainWindow) installUpdate() {
+	mw.gamesMutex.RLock()
+	if mw.selectedGame < 0 || mw.selectedGame >= len(mw.games) {
+		mw.gamesMutex.RUnlock()
+		dialog.ShowInformation("No Game Selected",
+			"Please select a game to install an update for.", mw.window)
+		return
+	}
+	selected := mw.games[mw.selectedGame]
+	mw.gamesMutex.RUnlock()
+
+	var downloads []string
+	if mw.settings.DownloadsDir != "" {
+		var err error
+		if downloads, err = game.FindDownloads(mw.settings.DownloadsDir, selected); err != nil {
+			fmt.Printf("Warning: Failed to read downloads folder: %v\n", err)
+		}
+	}
+
+	archiveEntry := widget.NewEntry()
+	archiveEntry.SetPlaceHolder("e.g., Game-0.8-pc.zip")
+	if len(downloads) > 0 {
+		archiveEntry.SetText(downloads[0])
+	}
+
+	downloadSelect := widget.NewSelect(downloads, func(path string) {
+		archiveEntry.SetText(path)
+	})
+	downloadSelect.PlaceHolder = "Matching archives in the downloads folder"
+	if len(downloads) == 0 {
+		downloadSelect.Disable()
+	}
+
+	browseBtn := widget.NewButton("Browse", func() {
+		openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				if err != nil {
+					dialog.ShowError(err, mw.window)
+				}
+				return
+			}
+			archiveEntry.SetText(reader.URI().Path())
+			reader.Close()
+		}, mw.window)
+		openDialog.SetFilter(fynestorage.NewExtensionFileFilter([]string{".zip", ".7z", ".rar", ".tar", ".gz", ".tgz", ".bz2", ".tbz2"}))
+		openDialog.Show()
+	})
+
+	migrateCheck := widget.NewCheck("Copy saves from the current version", nil)
+	migrateCheck.SetChecked(mw.settings.MigrateSaves)
+
+	currentText := selected.CurrentVersion
+	if currentText == "" {
+		currentText = "Unknown"
+	}
+
+	form := dialog.NewForm("Install Update", "Install", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Game", widget.NewLabel(fmt.Sprintf("%s (installed: %s)", selected.Name, currentText))),
+			widget.NewFormItem("Downloads", downloadSelect),
+			widget.NewFormItem("Archive", container.NewBorder(nil, nil, nil, browseBtn, archiveEntry)),
+			widget.NewFormItem("", migrateCheck),
+		},
+		func(confirm bool) {
+			archive := strings.TrimSpace(archiveEntry.Text)
+			if !confirm || archive == "" {
+				return
+			}
+			opts := game.NewInstallOptions(mw.settings)
+			opts.MigrateSaves = migrateCheck.Checked
+			mw.runInstall(selected, archive, opts)
+		},
+		mw.window)
+
+	form.Resize(fyne.NewSize(550, 300))
+	form.Show()
+}
+
+// runInstall extracts and switches to a new version in the background
+func (mw *MainWindow) runInstall(selected *models.Game, archive string, opts game.InstallOptions) {
+	progress := dialog.NewCustomWithoutButtons("Installing Update",
+		container.NewVBox(widget.NewLabel(fmt.Sprintf("Installing %s...", filepath.Base(archive))), widget.NewProgressBarInfinite()),
+		mw.window)
+	progress.Show()
+
+	go func() {
+		previousFolder := selected.Folder
+		err := mw.gameManager.InstallUpdate(selected, archive, opts)
+		progress.Hide()
+
+		// Removing old versions can fail after the switch, which is still kept
+		if selected.Folder != previousFolder {
+			mw.saveGame(selected)
+			mw.gameList.Refresh()
+		}
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		dialog.ShowInformation("Update Installed",
+			fmt.Sprintf("%s %s was installed to:\n%s", selected.Name, selected.CurrentVersion, selected.Folder), mw.window)
+	}()
+}
+
+// switchInstalledVersion rolls the selected game back to, or forward to, one
+// of the versions kept after installing updates
+func (mw *MainWindow) switchInstalledVersion() {
+	mw.gamesMutex.RLock()
+	if mw.selectedGame < 0 || mw.selectedGame >= len(mw.games) {
+		mw.gamesMutex.RUnlock()
+		dialog.ShowInformation("No Game Selected",
+			"Please select a game to switch its installed version.", mw.window)
+		return
 	}
+	selected := mw.games[mw.selectedGame]
+	mw.gamesMutex.RUnlock()
 
-	// Try to parse as semantic versions first
-	if mw.compareSemanticVersions(v1, v2) {
-		return true
+	if len(selected.InstalledVersions) == 0 {
+		dialog.ShowInformation("Switch Installed Version",
+			fmt.Sprintf("No other versions of '%s' are kept.\n\nVersions are kept when an update is installed with \"Game → Install Update\".", selected.Name), mw.window)
+		return
 	}
 
-	// Fallback to string comparison for non-semantic versions
-	return v1 > v2
+	var options []string
+	for _, installed := range selected.InstalledVersions {
+		label := installed.Version
+		if label == "" {
+			label = "Unknown version"
+		}
+		options = append(options, fmt.Sprintf("%s  -  %s", label, filepath.Base(installed.Folder)))
+	}
+	versionSelect := widget.NewSelect(options, nil)
+	versionSelect.SetSelectedIndex(0)
+
+	dialog.ShowForm("Switch Installed Version", "Switch", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Game", widget.NewLabel(selected.Name)),
+			widget.NewFormItem("Version", versionSelect),
+		},
+		func(confirm bool) {
+			if !confirm || versionSelect.SelectedIndex() < 0 {
+				return
+			}
+			if err := mw.gameManager.Rollback(selected, versionSelect.SelectedIndex()); err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+			mw.saveGame(selected)
+			mw.gameList.Refresh()
+		},
+		mw.window)
+}
+
+// saveReasonLabels describe why a save snapshot was taken
+var saveReasonLabels = map[string]string{
+	saves.ReasonLaunch:    "Before launch",
+	saves.ReasonUpdate:    "Before update",
+	saves.ReasonManual:    "Manual",
+	saves.ReasonRestore:   "Before restore",
+	saves.ReasonUninstall: "Before uninstall",
 }
 
-// compareSemanticVersions compares semantic version strings (e.g., "1.2.3")
-func (mw *MainWindow) compareSemanticVersions(v1, v2 string) bool {
-	// Split versions into parts
-	parts1 := strings.Split(v1, ".")
-	parts2 := strings.Split(v2, ".")
+// showSaveBackups lists the save snapshots of the selected game, newest
+// first, and restores or deletes them
+func (mw *MainWindow) showSaveBackups() {
+	mw.gamesMutex.RLock()
+	if mw.selectedGame < 0 || mw.selectedGame >= len(mw.games) {
+		mw.gamesMutex.RUnlock()
+		dialog.ShowInformation("No Game Selected",
+			"Please select a game to manage its save backups.", mw.window)
+		return
+	}
+	selected := mw.games[mw.selectedGame]
+	mw.gamesMutex.RUnlock()
+
+	snapshots, err := mw.saveManager.List(selected.ID)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to list save backups: %w", err), mw.window)
+		return
+	}
 
-	// Find the maximum length
-	maxLen := len(parts1)
-	if len(parts2) > maxLen {
-		maxLen = len(parts2)
+	locations := saves.Locations(selected)
+	locationText := "No save folders found. Set them in \"Edit\" if the game keeps saves elsewhere."
+	if len(locations) > 0 {
+		locationText = "Save folders:\n" + strings.Join(locations, "\n")
 	}
+	locationLabel := widget.NewLabel(locationText)
+	locationLabel.Wrapping = fyne.TextWrapWord
 
-	// Compare each part
-	for i := 0; i < maxLen; i++ {
-		var part1, part2 string
-		if i < len(parts1) {
-			part1 = parts1[i]
+	chosen := -1
+	snapshotList := widget.NewList(
+		func() int { return len(snapshots) },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Snapshot")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			snap := snapshots[id]
+			reason := saveReasonLabels[snap.Reason]
+			if reason == "" {
+				reason = snap.Reason
+			}
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s  -  %s  -  %d files, %s",
+				snap.Created.Format("2006-01-02 15:04"), reason, snap.Files, models.FormatSize(snap.Size)))
+		},
+	)
+	snapshotList.OnSelected = func(id widget.ListItemID) { chosen = int(id) }
+
+	reload := func() {
+		if snapshots, err = mw.saveManager.List(selected.ID); err != nil {
+			dialog.ShowError(err, mw.window)
 		}
-		if i < len(parts2) {
-			part2 = parts2[i]
+		chosen = -1
+		snapshotList.UnselectAll()
+		snapshotList.Refresh()
+	}
+
+	backupBtn := widget.NewButton("Back Up Now", func() {
+		snap, err := mw.saveManager.Snapshot(selected, saves.ReasonManual)
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
 		}
+		if snap == nil {
+			dialog.ShowInformation("Save Backups", "There are no saves to back up.", mw.window)
+			return
+		}
+		reload()
+	})
 
-		// Convert to integers for comparison
-		num1 := mw.parseVersionPart(part1)
-		num2 := mw.parseVersionPart(part2)
+	restoreBtn := widget.NewButton("Restore", func() {
+		if chosen < 0 || chosen >= len(snapshots) {
+			return
+		}
+		snap := snapshots[chosen]
+		if mw.gameManager.IsRunning(selected.ID) {
+			dialog.ShowInformation("Game Running", "Close the game before restoring its saves.", mw.window)
+			return
+		}
+		dialog.ShowConfirm("Restore Saves",
+			fmt.Sprintf("Restore the saves of '%s' from %s?\n\nThe current saves are backed up first.",
+				selected.Name, snap.Created.Format("2006-01-02 15:04")),
+			func(confirm bool) {
+				if !confirm {
+					return
+				}
+				if err := mw.saveManager.Restore(selected, snap); err != nil {
+					dialog.ShowError(fmt.Errorf("failed to restore saves: %w", err), mw.window)
+				}
+				reload()
+			}, mw.window)
+	})
+
+	deleteBtn := widget.NewButton("Delete", func() {
+		if chosen < 0 || chosen >= len(snapshots) {
+			return
+		}
+		if err := mw.saveManager.Delete(snapshots[chosen]); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+		reload()
+	})
+
+	content := container.NewBorder(
+		locationLabel, container.NewHBox(backupBtn, restoreBtn, deleteBtn), nil, nil,
+		snapshotList,
+	)
+
+	backupDialog := dialog.NewCustom(fmt.Sprintf("Save Backups - %s", selected.Name), "Close", content, mw.window)
+	backupDialog.Resize(fyne.NewSize(600, 450))
+	backupDialog.Show()
+}
+
+// showVersionHistory shows every version seen for the selected game
+func (mw *MainWindow) showVersionHistory() {
+	mw.gamesMutex.RLock()
+	if mw.selectedGame < 0 || mw.selectedGame >= len(mw.games) {
+		mw.gamesMutex.RUnlock()
+		dialog.ShowInformation("No Game Selected",
+			"Please select a game to view its version history.", mw.window)
+		return
+	}
+
+	game := mw.games[mw.selectedGame]
+	mw.gamesMutex.RUnlock()
+
+	records, err := mw.storage.VersionHistory(game.ID)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to load version history: %w", err), mw.window)
+		return
+	}
+
+	if len(records) == 0 {
+		dialog.ShowInformation("Version History",
+			fmt.Sprintf("No versions have been recorded for '%s' yet.\n\nVersions are recorded each time an update check finds one.", game.Name), mw.window)
+		return
+	}
+
+	// Summarise how active the developer is
+	first := records[0].FirstSeen
+	last := records[len(records)-1].FirstSeen
+	summary := fmt.Sprintf("%d versions seen since %s", len(records), first.Format("2006-01-02"))
+	if len(records) > 1 {
+		avgDays := last.Sub(first).Hours() / 24 / float64(len(records)-1)
+		summary += fmt.Sprintf(", one every %.0f days on average", avgDays)
+	}
+	summary += fmt.Sprintf(".\nLatest version first seen %.0f days ago.", time.Since(last).Hours()/24)
+
+	changelogLabel := widget.NewLabel("Select a version to see its changelog.")
+	changelogLabel.Wrapping = fyne.TextWrapWord
+
+	// Show the newest version at the top
+	historyList := widget.NewList(
+		func() int { return len(records) },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Version")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			index := len(records) - 1 - int(id)
+			record := records[index]
+
+			text := fmt.Sprintf("%s  -  %s", record.Version, record.FirstSeen.Format("2006-01-02"))
+			if index > 0 {
+				gap := record.FirstSeen.Sub(records[index-1].FirstSeen).Hours() / 24
+				text += fmt.Sprintf("  (+%.0f days)", gap)
+			}
+			obj.(*widget.Label).SetText(text)
+		},
+	)
+	historyList.OnSelected = func(id widget.ListItemID) {
+		record := records[len(records)-1-int(id)]
+		if record.Changelog != "" {
+			changelogLabel.SetText(record.Changelog)
+		} else {
+			changelogLabel.SetText("No changelog recorded for this version.")
+		}
+	}
+
+	content := container.NewBorder(
+		widget.NewLabel(summary), nil, nil, nil,
+		container.NewVSplit(historyList, container.NewVScroll(changelogLabel)),
+	)
+
+	historyDialog := dialog.NewCustom(fmt.Sprintf("Version History - %s", game.Name), "Close", content, mw.window)
+	historyDialog.Resize(fyne.NewSize(600, 450))
+	historyDialog.Show()
+}
+
+// updateFetchedVersionLabel updates the fetched version label with cached information only
+func (mw *MainWindow) updateFetchedVersionLabel(game *models.Game, label *ColoredLabel) {
+	// If no source URL, show as unavailable
+	if game.SourceURL == "" {
+		label.SetText("No source")
+		return
+	}
+
+	// Show why the last check failed, distinguishing removed sources from
+	// problems that will likely go away on their own
+	if game.LastErrorKind != "" {
+		kind := monitor.ErrorKind(game.LastErrorKind)
+		bgColor := color.Color(color.NRGBA{R: 128, G: 128, B: 128, A: 100}) // Light gray
+		if kind.Permanent() {
+			bgColor = color.NRGBA{R: 139, G: 0, B: 0, A: 160} // Dark red
+		}
+		if label.text != kind.Label() || label.bgColor != bgColor {
+			label.SetText(kind.Label())
+			label.SetColors(bgColor, color.White)
+		}
+		return
+	}
+
+	// Check if we have cached version information
+	if game.Version != "" {
+		// Display cached version information
+		var displayText string
+		var bgColor, textColor color.Color
 
-		if num1 > num2 {
-			return true
-		} else if num1 < num2 {
-			return false
+		if game.Version == game.CurrentVersion || (game.CurrentVersion != "" && version.Compare(game.Version, game.CurrentVersion) == 0) {
+			// Same version - show with green background
+			displayText = game.Version
+			bgColor = color.NRGBA{R: 0, G: 255, B: 0, A: 100} // Light green
+			textColor = color.Black
+		} else if game.Version != "" {
+			// Different version - determine if it's newer
+			if version.IsNewer(game.Version, game.CurrentVersion) {
+				// Newer version available - show with red background
+				displayText = game.Version + " [NEW]"
+				bgColor = color.NRGBA{R: 255, G: 0, B: 0, A: 100} // Light red
+				textColor = color.White
+			} else {
+				// Different version but not newer - show with orange background
+				displayText = game.Version + " [DIFF]"
+				bgColor = color.NRGBA{R: 255, G: 165, B: 0, A: 100} // Light orange
+				textColor = color.Black
+			}
+		} else {
+			// No cached version
+			displayText = "Not checked"
+			bgColor = color.NRGBA{R: 128, G: 128, B: 128, A: 100} // Light gray
+			textColor = color.Black
 		}
-	}
-
-	return false // Versions are equal
-}
 
-// parseVersionPart converts a version part string to an integer
-func (mw *MainWindow) parseVersionPart(part string) int {
-	// Remove any non-numeric characters
-	clean := ""
-	for _, char := range part {
-		if char >= '0' && char <= '9' {
-			clean += string(char)
+		// Update the label only if something changed to avoid unnecessary refreshes
+		newText := mw.truncateText(displayText, 20)
+		if label.text != newText || label.bgColor != bgColor || label.textColor != textColor {
+			label.SetText(newText)
+			label.SetColors(bgColor, textColor)
+		}
+	} else {
+		// No cached version - show as not checked
+		// Only update if required
+		if label.text != "Not checked" || label.bgColor != (color.NRGBA{R: 128, G: 128, B: 128, A: 100}) {
+			label.SetText("Not checked")
+			label.SetColors(color.NRGBA{R: 128, G: 128, B: 128, A: 100}, color.Black)
 		}
 	}
+}
 
-	if clean == "" {
-		return 0
-	}
-
-	// Convert to integer
-	if num, err := strconv.Atoi(clean); err == nil {
-		return num
+// truncateText truncates text to the specified length and adds ellipsis if needed
+func (mw *MainWindow) truncateText(text string, maxLength int) string {
+	if len(text) <= maxLength {
+		return text
 	}
-
-	return 0
+	return text[:maxLength-3] + "..."
 }
 
 // refreshAllVersionChecks refreshes version checks for all games
 func (mw *MainWindow) refreshAllVersionChecks() {
 	// Run initial version checks for all games at startup
 	go func() {
-		// Get a copy of games to iterate over (to avoid holding lock for too long)
-		mw.gamesMutex.RLock()
-		gamesCopy := make([]*models.Game, len(mw.games))
-		copy(gamesCopy, mw.games)
-		mw.gamesMutex.RUnlock()
+		ctx := mw.beginUpdateCheck()
+		defer mw.endUpdateCheck(ctx)
 
+		gamesCopy := mw.copyGames()
 		fmt.Printf("DEBUG: Running startup version checks for %d games\n", len(gamesCopy))
 
-		for _, game := range gamesCopy {
-			if game.SourceURL != "" {
-				fmt.Printf("DEBUG: Checking version for %s\n", game.Name)
-				updateInfo, err := mw.monitor.CheckForUpdates(game)
-				if err == nil {
-					game.UpdateInfo(updateInfo.Version)
-					game.MarkChecked()
-					fmt.Printf("DEBUG: Updated %s version to %s\n", game.Name, updateInfo.Version)
-				} else {
-					fmt.Printf("DEBUG: Error checking %s: %v\n", game.Name, err)
-				}
+		for result := range mw.newChecker().Run(ctx, gamesCopy) {
+			if result.Err != nil {
+				mw.recordCheckError(ctx, result)
+				continue
 			}
+			mw.applyCheckResult(result)
+			fmt.Printf("DEBUG: Updated %s version to %s\n", result.Game.Name, result.Info.Version)
 		}
 
 		// Save the updated version information
@@ -1131,33 +2250,39 @@ func (mw *MainWindow) refreshAllVersionChecks() {
 
 // checkAllUpdates checks for updates on all games
 func (mw *MainWindow) checkAllUpdates() {
-	progress := dialog.NewProgress("Checking Updates", "Checking for game updates...", mw.window)
+	ctx := mw.beginUpdateCheck()
+
+	progressBar := widget.NewProgressBar()
+	statusLabel := widget.NewLabel("Checking for game updates...")
+	progress := dialog.NewCustom("Checking Updates", "Cancel",
+		container.NewVBox(statusLabel, progressBar), mw.window)
+	progress.SetOnClosed(func() {
+		mw.cancelUpdateCheck(ctx)
+	})
 	progress.Show()
 
 	go func() {
 		defer progress.Hide()
+		defer mw.endUpdateCheck(ctx)
 
-		// Get a copy of games to iterate over (to avoid holding lock for too long)
-		mw.gamesMutex.RLock()
-		gamesCopy := make([]*models.Game, len(mw.games))
-		copy(gamesCopy, mw.games)
-		mw.gamesMutex.RUnlock()
+		for result := range mw.newChecker().Run(ctx, mw.copyGames()) {
+			progressBar.SetValue(float64(result.Done) / float64(result.Total))
+			statusLabel.SetText(fmt.Sprintf("Checked %d of %d: %s", result.Done, result.Total, result.Game.Name))
 
-		for i, game := range gamesCopy {
-			progress.SetValue(float64(i) / float64(len(gamesCopy)))
+			if result.Err != nil {
+				mw.recordCheckError(ctx, result)
+				continue
+			}
 
-			if game.SourceURL != "" {
-				updateInfo, err := mw.monitor.CheckForUpdates(game)
-				if err == nil {
-					game.UpdateInfo(updateInfo.Version)
-					game.MarkChecked()
-
-					// Show notification only if there's an update
-					if updateInfo.HasUpdate && mw.settings.Notifications {
-						dialog.ShowInformation("Update Available",
-							fmt.Sprintf("%s has an update available: %s", game.Name, updateInfo.Version), mw.window)
-					}
-				}
+			mw.applyCheckResult(result)
+
+			// Show notification only if there's an update
+			if result.Info.HasUpdate && mw.settings.Notifications {
+				mw.showUpdateNotification(result.Game, result.Info)
+			}
+			if result.Info.StatusChanged && mw.settings.Notifications {
+				dialog.ShowInformation("Development Status Changed",
+					fmt.Sprintf("%s is now marked %s (was %s).", result.Game.Name, result.Info.Status, result.Info.PreviousStatus), mw.window)
 			}
 		}
 
@@ -1166,6 +2291,136 @@ func (mw *MainWindow) checkAllUpdates() {
 	}()
 }
 
+// showUpdateNotification tells the user about an update, including what
+// changed when the source publishes a changelog
+func (mw *MainWindow) showUpdateNotification(game *models.Game, info *monitor.UpdateInfo) {
+	message := fmt.Sprintf("%s has an update available: %s", game.Name, info.Version)
+
+	var details []string
+	if info.Developer != "" {
+		details = append(details, fmt.Sprintf("Developer: %s", info.Developer))
+	}
+	if !info.ThreadUpdated.IsZero() {
+		details = append(details, fmt.Sprintf("Thread updated: %s", info.ThreadUpdated.Format("2006-01-02")))
+	}
+	if len(info.OS) > 0 {
+		details = append(details, fmt.Sprintf("OS: %s", strings.Join(info.OS, ", ")))
+	}
+	if len(details) > 0 {
+		message += "\n\n" + strings.Join(details, "\n")
+	}
+
+	if info.Changelog == "" {
+		dialog.ShowInformation("Update Available", message, mw.window)
+		return
+	}
+
+	changelogLabel := widget.NewLabel(info.ChangelogExcerpt(40))
+	changelogLabel.Wrapping = fyne.TextWrapWord
+
+	content := container.NewBorder(
+		widget.NewLabel(message), nil, nil, nil,
+		container.NewVScroll(changelogLabel),
+	)
+
+	notification := dialog.NewCustom("Update Available", "OK", content, mw.window)
+	notification.Resize(fyne.NewSize(550, 450))
+	notification.Show()
+}
+
+// applyCheckResult stores a successful check on the game and in its version history
+func (mw *MainWindow) applyCheckResult(result monitor.CheckResult) {
+	result.Game.UpdateInfo(result.Info.Version)
+	result.Game.UpdateSourceStatus(result.Info.Status)
+	result.Game.MarkChecked()
+
+	if result.Info.Version == "" {
+		return
+	}
+	record := models.VersionRecord{
+		Version:   result.Info.Version,
+		FirstSeen: time.Now(),
+		SourceURL: result.Game.SourceURL,
+		Changelog: result.Info.Changelog,
+	}
+	if _, err := mw.storage.RecordVersion(result.Game.ID, record); err != nil {
+		fmt.Printf("Warning: Failed to record version history for %s: %v\n", result.Game.Name, err)
+	}
+}
+
+// recordCheckError stores a failed check on the game unless the check was cancelled
+func (mw *MainWindow) recordCheckError(ctx context.Context, result monitor.CheckResult) {
+	if ctx.Err() != nil {
+		return
+	}
+	result.Game.MarkCheckFailed(string(monitor.KindOf(result.Err)), result.Err.Error())
+}
+
+// newChecker creates an update checker configured from the current settings
+func (mw *MainWindow) newChecker() *monitor.Checker {
+	opts := monitor.DefaultCheckerOptions()
+	opts.Workers = mw.settings.CheckWorkers
+	opts.HostRate = mw.settings.CheckRatePerHost
+	return monitor.NewChecker(mw.monitor, opts)
+}
+
+// copyGames returns a snapshot of the games slice to iterate over without
+// holding the lock for too long
+func (mw *MainWindow) copyGames() []*models.Game {
+	mw.gamesMutex.RLock()
+	defer mw.gamesMutex.RUnlock()
+
+	gamesCopy := make([]*models.Game, len(mw.games))
+	copy(gamesCopy, mw.games)
+	return gamesCopy
+}
+
+// beginUpdateCheck cancels any running update check and returns the context
+// for a new one
+func (mw *MainWindow) beginUpdateCheck() context.Context {
+	mw.checkMutex.Lock()
+	defer mw.checkMutex.Unlock()
+
+	if mw.checkCancel != nil {
+		mw.checkCancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mw.checkCtx = ctx
+	mw.checkCancel = cancel
+	return ctx
+}
+
+// cancelUpdateCheck cancels the update check identified by ctx if it is still running
+func (mw *MainWindow) cancelUpdateCheck(ctx context.Context) {
+	mw.checkMutex.Lock()
+	defer mw.checkMutex.Unlock()
+
+	if mw.checkCtx == ctx && mw.checkCancel != nil {
+		mw.checkCancel()
+	}
+}
+
+// isUpdateCheckRunning reports whether an update check is in progress
+func (mw *MainWindow) isUpdateCheckRunning() bool {
+	mw.checkMutex.Lock()
+	defer mw.checkMutex.Unlock()
+
+	return mw.checkCtx != nil
+}
+
+// endUpdateCheck releases the resources of the update check identified by ctx
+func (mw *MainWindow) endUpdateCheck(ctx context.Context) {
+	mw.checkMutex.Lock()
+	defer mw.checkMutex.Unlock()
+
+	if mw.checkCtx == ctx {
+		mw.checkCancel()
+		mw.checkCtx = nil
+		mw.checkCancel = nil
+	}
+}
+
 // showSettings shows the settings dialog
 func (mw *MainWindow) showSettings() {
 	intervalEntry := widget.NewEntry()
@@ -1174,10 +2429,70 @@ func (mw *MainWindow) showSettings() {
 	notificationsCheck := widget.NewCheck("Enable Notifications", nil)
 	notificationsCheck.SetChecked(mw.settings.Notifications)
 
+	workersEntry := widget.NewEntry()
+	workersEntry.SetText(fmt.Sprintf("%d", mw.settings.CheckWorkers))
+
+	rateEntry := widget.NewEntry()
+	rateEntry.SetText(fmt.Sprintf("%g", mw.settings.CheckRatePerHost))
+
+	backupsEntry := widget.NewEntry()
+	backupsEntry.SetText(fmt.Sprintf("%d", mw.settings.BackupCount))
+
+	wineEntry := widget.NewEntry()
+	wineEntry.SetText(mw.settings.WinePath)
+	wineEntry.SetPlaceHolder("wine")
+
+	protonEntry := widget.NewEntry()
+	protonEntry.SetText(mw.settings.ProtonPath)
+	protonEntry.SetPlaceHolder(".../steamapps/common/Proton 8.0/proton")
+
+	libraryRootsEntry := widget.NewMultiLineEntry()
+	libraryRootsEntry.SetText(strings.Join(mw.settings.LibraryRoots, "\n"))
+	libraryRootsEntry.SetPlaceHolder("Watched folders, one per line")
+
+	scanIgnoreEntry := widget.NewEntry()
+	scanIgnoreEntry.SetText(strings.Join(mw.settings.ScanIgnore, ", "))
+	scanIgnoreEntry.SetPlaceHolder("e.g., .*, __MACOSX, *redist*")
+
+	scanDepthEntry := widget.NewEntry()
+	scanDepthEntry.SetText(fmt.Sprintf("%d", mw.settings.ScanMaxDepth))
+
+	downloadsEntry := widget.NewEntry()
+	downloadsEntry.SetText(mw.settings.DownloadsDir)
+	downloadsEntry.SetPlaceHolder("Folder searched for update archives")
+
+	keepVersionsEntry := widget.NewEntry()
+	keepVersionsEntry.SetText(fmt.Sprintf("%d", mw.settings.KeepVersions))
+
+	migrateSavesCheck := widget.NewCheck("Copy saves into installed updates", nil)
+	migrateSavesCheck.SetChecked(mw.settings.MigrateSaves)
+
+	saveSnapshotsEntry := widget.NewEntry()
+	saveSnapshotsEntry.SetText(fmt.Sprintf("%d", mw.settings.SaveSnapshots))
+
+	backendSelect := widget.NewSelect([]string{storage.BackendJSON, storage.BackendSQLite}, nil)
+	backendSelect.SetSelected(mw.settings.StorageBackend)
+	if backendSelect.Selected == "" {
+		backendSelect.SetSelected(storage.BackendJSON)
+	}
+
 	form := dialog.NewForm("Settings", "Save", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("Check Interval (seconds)", intervalEntry),
 			widget.NewFormItem("", notificationsCheck),
+			widget.NewFormItem("Parallel Checks", workersEntry),
+			widget.NewFormItem("Requests/sec per Site", rateEntry),
+			widget.NewFormItem("Library Backups to Keep", backupsEntry),
+			widget.NewFormItem("Wine Binary", wineEntry),
+			widget.NewFormItem("Proton Script", protonEntry),
+			widget.NewFormItem("Library Folders", libraryRootsEntry),
+			widget.NewFormItem("Scan Ignore Patterns", scanIgnoreEntry),
+			widget.NewFormItem("Scan Depth (0 = unlimited)", scanDepthEntry),
+			widget.NewFormItem("Downloads Folder", downloadsEntry),
+			widget.NewFormItem("Old Versions to Keep", keepVersionsEntry),
+			widget.NewFormItem("", migrateSavesCheck),
+			widget.NewFormItem("Save Backups per Game", saveSnapshotsEntry),
+			widget.NewFormItem("Storage Backend", backendSelect),
 		},
 		func(confirm bool) {
 			if !confirm {
@@ -1189,25 +2504,363 @@ func (mw *MainWindow) showSettings() {
 				mw.settings.CheckInterval = 3600
 			}
 			mw.settings.Notifications = notificationsCheck.Checked
+			if workers, err := strconv.Atoi(strings.TrimSpace(workersEntry.Text)); err == nil && workers > 0 {
+				mw.settings.CheckWorkers = workers
+			}
+			if rate, err := strconv.ParseFloat(strings.TrimSpace(rateEntry.Text), 64); err == nil && rate > 0 {
+				mw.settings.CheckRatePerHost = rate
+			}
+			if backups, err := strconv.Atoi(strings.TrimSpace(backupsEntry.Text)); err == nil && backups >= 0 {
+				mw.settings.BackupCount = backups
+				mw.storage.SetBackupCount(backups)
+			}
+			mw.settings.WinePath = strings.TrimSpace(wineEntry.Text)
+			mw.settings.ProtonPath = strings.TrimSpace(protonEntry.Text)
+			mw.gameManager.SetRunnerConfig(game.NewRunnerConfig(mw.settings, mw.storage.DataPath()))
+			mw.settings.ScanIgnore = parseTags(scanIgnoreEntry.Text)
+			if depth, err := strconv.Atoi(strings.TrimSpace(scanDepthEntry.Text)); err == nil && depth >= 0 {
+				mw.settings.ScanMaxDepth = depth
+			}
+			mw.gameManager.SetScanOptions(game.NewScanOptions(mw.settings))
+			roots := parseLines(libraryRootsEntry.Text)
+			rootsChanged := strings.Join(roots, "\n") != strings.Join(mw.settings.LibraryRoots, "\n")
+			mw.settings.LibraryRoots = roots
+			mw.settings.DownloadsDir = strings.TrimSpace(downloadsEntry.Text)
+			if keep, err := strconv.Atoi(strings.TrimSpace(keepVersionsEntry.Text)); err == nil && keep >= 0 {
+				mw.settings.KeepVersions = keep
+			}
+			mw.settings.MigrateSaves = migrateSavesCheck.Checked
+			if snapshots, err := strconv.Atoi(strings.TrimSpace(saveSnapshotsEntry.Text)); err == nil && snapshots >= 0 {
+				mw.settings.SaveSnapshots = snapshots
+				mw.saveManager.SetKeep(snapshots)
+			}
+
+			if backendSelect.Selected != mw.settings.StorageBackend {
+				mw.settings.StorageBackend = backendSelect.Selected
+
+				// Bring the new backend up to date so nothing is lost
+				if err := storage.WriteLibrary(mw.storage, backendSelect.Selected, mw.copyGames()); err != nil {
+					dialog.ShowError(fmt.Errorf("failed to write the library for the new backend: %w", err), mw.window)
+				}
+				dialog.ShowInformation("Restart Required",
+					"The new storage backend is used after restarting the launcher.", mw.window)
+			}
 
 			mw.saveSettings()
 			mw.restartUpdateTimer()
+			if rootsChanged {
+				mw.startLibraryWatcher()
+			}
 		},
 		mw.window)
 
-	form.Resize(fyne.NewSize(400, 200))
+	form.Resize(fyne.NewSize(450, 700))
 	form.Show()
 }
 
-// saveGames saves the games list to storage
+// saveGame stores a single added or edited game
+func (mw *MainWindow) saveGame(game *models.Game) {
+	if err := mw.store.SaveGame(game); err != nil {
+		dialog.ShowError(err, mw.window)
+	}
+}
+
+// saveGames stores every game after bulk changes such as update checks.
+// Games that another launcher process added or changed in the meantime are
+// merged in, not overwritten.
 func (mw *MainWindow) saveGames() {
-	mw.gamesMutex.RLock()
-	defer mw.gamesMutex.RUnlock()
+	mw.syncMutex.Lock()
+	defer mw.syncMutex.Unlock()
 
-	err := mw.storage.SaveGames(mw.games)
+	// The list is written without holding gamesMutex, so the window stays
+	// responsive while the library is saved
+	saved := mw.copyGames()
+	merged, err := storage.SaveAll(mw.store, saved)
 	if err != nil {
 		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	mw.gamesMutex.Lock()
+	changed := mw.replaceGamesLocked(keepLocalChanges(saved, mw.games, merged))
+	mw.gamesMutex.Unlock()
+
+	if changed {
+		mw.refreshGameList()
+	}
+}
+
+// startLibraryWatcher (re)starts watching the library folders from the
+// settings and checks them once right away
+func (mw *MainWindow) startLibraryWatcher() {
+	mw.watchMutex.Lock()
+	if mw.libraryWatcher != nil {
+		mw.libraryWatcher.Close()
+		mw.libraryWatcher = nil
+	}
+	roots := append([]string(nil), mw.settings.LibraryRoots...)
+	mw.watchMutex.Unlock()
+
+	if len(roots) == 0 {
+		return
+	}
+
+	watcher, err := game.NewWatcher(roots, mw.reconcileLibrary)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("failed to watch library folders: %w", err), mw.window)
+		return
+	}
+	mw.watchMutex.Lock()
+	mw.libraryWatcher = watcher
+	mw.watchMutex.Unlock()
+
+	go mw.reconcileLibrary()
+}
+
+// reconcileLibrary brings the library in line with the library folders:
+// missing games are marked, moved games re-linked and new game folders
+// offered as pending imports. It runs in the background.
+func (mw *MainWindow) reconcileLibrary() {
+	mw.watchMutex.Lock()
+	roots := append([]string(nil), mw.settings.LibraryRoots...)
+	watcher := mw.libraryWatcher
+	mw.watchMutex.Unlock()
+
+	games := mw.copyGames()
+	changes, err := mw.gameManager.Reconcile(games, roots)
+	if err != nil {
+		fmt.Printf("Warning: Failed to check library folders: %v\n", err)
+	}
+
+	if changes.Changed() {
+		mw.saveGames()
+		mw.refreshGameList()
+	}
+
+	if watcher != nil {
+		var folders []string
+		for _, g := range games {
+			folders = append(folders, g.Folder)
+		}
+		watcher.WatchFolders(folders)
+	}
+
+	mw.watchMutex.Lock()
+	known := make(map[string]bool)
+	for _, pending := range mw.pendingImports {
+		known[pending.Folder] = true
+	}
+	var pending []*models.Game
+	added := 0
+	for _, candidate := range changes.Pending {
+		if mw.ignoredImports[candidate.Folder] {
+			continue
+		}
+		if !known[candidate.Folder] {
+			added++
+		}
+		pending = append(pending, candidate)
+	}
+	mw.pendingImports = pending
+	mw.watchMutex.Unlock()
+
+	if added > 0 && mw.settings.Notifications {
+		dialog.ShowInformation("New Games Found",
+			fmt.Sprintf("%d new games were found in your library folders.\n\nOpen \"Library → Pending Imports\" to add them.", added), mw.window)
+	}
+}
+
+// showPendingImports lists the games found in the library folders and
+// imports the checked ones. Unchecked ones are not offered again until the
+// launcher restarts.
+func (mw *MainWindow) showPendingImports() {
+	mw.watchMutex.Lock()
+	pending := append([]*models.Game(nil), mw.pendingImports...)
+	mw.watchMutex.Unlock()
+
+	if len(pending) == 0 {
+		message := "No new games were found in the library folders."
+		if len(mw.settings.LibraryRoots) == 0 {
+			message = "Set the library folders to watch in the settings."
+		}
+		dialog.ShowInformation("Pending Imports", message, mw.window)
+		return
+	}
+
+	checks := make([]*widget.Check, len(pending))
+	items := container.NewVBox()
+	for i, candidate := range pending {
+		label := fmt.Sprintf("%s  (%s)", candidate.Name, candidate.Folder)
+		if candidate.Engine != "" {
+			label = fmt.Sprintf("%s [%s]  (%s)", candidate.Name, candidate.Engine, candidate.Folder)
+		}
+		checks[i] = widget.NewCheck(label, nil)
+		checks[i].SetChecked(true)
+		items.Add(checks[i])
+	}
+
+	importDialog := dialog.NewCustomConfirm("Pending Imports", "Import", "Later", container.NewVScroll(items),
+		func(confirm bool) {
+			if !confirm {
+				return
+			}
+			imported := make(map[string]bool)
+			mw.gamesMutex.Lock()
+			for i, candidate := range pending {
+				if checks[i].Checked {
+					mw.games = append(mw.games, candidate)
+					imported[candidate.Folder] = true
+				}
+			}
+			mw.gamesMutex.Unlock()
+
+			mw.watchMutex.Lock()
+			var remaining []*models.Game
+			for i, candidate := range pending {
+				if !checks[i].Checked {
+					mw.ignoredImports[candidate.Folder] = true
+				}
+			}
+			for _, candidate := range mw.pendingImports {
+				if !imported[candidate.Folder] && !mw.ignoredImports[candidate.Folder] {
+					remaining = append(remaining, candidate)
+				}
+			}
+			mw.pendingImports = remaining
+			mw.watchMutex.Unlock()
+
+			mw.saveGames()
+			mw.gameList.Refresh()
+		}, mw.window)
+	importDialog.Resize(fyne.NewSize(600, 400))
+	importDialog.Show()
+}
+
+// watchLibrary reloads the game list when another launcher process, such as
+// a command line invocation, changes the library on disk
+func (mw *MainWindow) watchLibrary() {
+	ticker := time.NewTicker(libraryPollInterval)
+	go func() {
+		for range ticker.C {
+			// A running check saves (and merges) when it finishes
+			detector, ok := mw.store.(storage.ChangeDetector)
+			if !ok || mw.isUpdateCheckRunning() || !detector.Changed() {
+				continue
+			}
+
+			mw.syncMutex.Lock()
+			saved := mw.copyGames()
+			games, err := mw.store.LoadGames()
+			if err != nil {
+				mw.syncMutex.Unlock()
+				fmt.Printf("Warning: Failed to reload game library: %v\n", err)
+				continue
+			}
+
+			mw.gamesMutex.Lock()
+			changed := mw.replaceGamesLocked(keepLocalChanges(saved, mw.games, games))
+			mw.gamesMutex.Unlock()
+			mw.syncMutex.Unlock()
+
+			if changed {
+				mw.refreshGameList()
+			}
+		}
+	}()
+}
+
+// refreshGameList redraws the list after the games were replaced, moving the
+// list's selection to wherever the selected game ended up
+func (mw *MainWindow) refreshGameList() {
+	mw.gamesMutex.RLock()
+	selected := -1
+	for row, i := range mw.visibleGamesLocked() {
+		if i == mw.selectedGame {
+			selected = row
+			break
+		}
+	}
+	mw.gamesMutex.RUnlock()
+
+	mw.gameList.Refresh()
+	if selected >= 0 {
+		mw.gameList.Select(selected)
+	} else {
+		mw.gameList.UnselectAll()
+	}
+}
+
+// replaceGamesLocked takes over a games list read back from storage, keeping
+// the selected game selected. Games already listed keep their object and get
+// the stored fields copied in, so edits through a game held by a dialog or a
+// running check still reach the list. It reports whether the list differs.
+// Callers must hold gamesMutex.
+func (mw *MainWindow) replaceGamesLocked(games []*models.Game) bool {
+	listed := make(map[string]*models.Game, len(mw.games))
+	for _, game := range mw.games {
+		listed[game.ID] = game
+	}
+
+	changed := len(games) != len(mw.games)
+	replaced := make([]*models.Game, len(games))
+	for i, game := range games {
+		if existing, ok := listed[game.ID]; ok {
+			if existing != game {
+				*existing = *game
+				changed = true
+			}
+			game = existing
+		}
+		replaced[i] = game
+		changed = changed || game != mw.games[i]
+	}
+	if !changed {
+		return false
+	}
+
+	selectedID := ""
+	if mw.selectedGame >= 0 && mw.selectedGame < len(mw.games) {
+		selectedID = mw.games[mw.selectedGame].ID
+	}
+
+	mw.games = replaced
+	mw.selectedGame = -1
+	for i, game := range replaced {
+		if selectedID != "" && game.ID == selectedID {
+			mw.selectedGame = i
+			break
+		}
+	}
+	return true
+}
+
+// keepLocalChanges applies the games added to or removed from the list while
+// saved was written or stored was read to stored, so they aren't undone
+// before their own save
+func keepLocalChanges(saved, current, stored []*models.Game) []*models.Game {
+	wasSaved := make(map[string]bool, len(saved))
+	for _, game := range saved {
+		wasSaved[game.ID] = true
+	}
+	isCurrent := make(map[string]bool, len(current))
+	for _, game := range current {
+		isCurrent[game.ID] = true
 	}
+
+	var games []*models.Game
+	isStored := make(map[string]bool, len(stored))
+	for _, game := range stored {
+		isStored[game.ID] = true
+		if !wasSaved[game.ID] || isCurrent[game.ID] {
+			games = append(games, game)
+		}
+	}
+	for _, game := range current {
+		if !wasSaved[game.ID] && !isStored[game.ID] {
+			games = append(games, game)
+		}
+	}
+	return games
 }
 
 // saveSettings saves the settings to storage
@@ -1305,7 +2958,7 @@ func (mw *MainWindow) searchForGame() {
 
 		// Save the changes
 		fmt.Printf("DEBUG: Saving games to storage\n")
-		mw.saveGames()
+		mw.saveGame(selectedGame)
 		fmt.Printf("DEBUG: Refreshing game list\n")
 		mw.gameList.Refresh()
 
@@ -1461,7 +3114,7 @@ func (mw *MainWindow) redownloadImageForGame(game *models.Game) {
 		imagePath, err := mw.searchService.ExtractImageFromSourceURL(game.SourceURL)
 		if err == nil && imagePath != "" {
 			game.ImagePath = imagePath
-			mw.saveGames()
+			mw.saveGame(game)
 			mw.gameList.Refresh()
 			fmt.Printf("DEBUG: Successfully extracted image from source URL for %s: %s\n", game.Name, imagePath)
 			return
@@ -1492,7 +3145,7 @@ func (mw *MainWindow) redownloadImageForGame(game *models.Game) {
 			if err == nil && bestMatch.ImagePath != "" {
 				// Update the game's image path
 				game.ImagePath = bestMatch.ImagePath
-				mw.saveGames()
+				mw.saveGame(game)
 				mw.gameList.Refresh()
 				fmt.Printf("DEBUG: Successfully re-downloaded image for %s: %s\n", game.Name, game.ImagePath)
 			} else {
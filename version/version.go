@@ -0,0 +1,203 @@
+package version
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is a parsed, comparable representation of a release label as used
+// by F95zone threads, itch.io pages and GitHub tags.
+type Version struct {
+	Raw     string
+	Season  int       // "Season 2"
+	Chapter int       // "Ch.4", "Chapter 4"
+	Episode int       // "Ep.2", "Episode 2"
+	Numbers []int     // dotted numeric part, e.g. [0 12 3] for "v0.12.3b"
+	Suffix  string    // letter attached to the numeric part, e.g. "b" for "v0.12.3b"
+	Pre     int       // pre-release rank: 0 for releases, negative for alpha/beta/rc
+	PreNum  int       // number of the pre-release, e.g. 2 for "1.0-rc2"
+	Build   time.Time // date stamp, e.g. "Build 2024-05-01"
+	Final   bool      // "Final" or "Completed" marker
+}
+
+var (
+	datePattern    = regexp.MustCompile(`(\d{4})[-._/](\d{1,2})[-._/](\d{1,2})`)
+	seasonPattern  = regexp.MustCompile(`\bseason\s*\.?\s*(\d+)`)
+	chapterPattern = regexp.MustCompile(`\b(?:chapter|ch)\s*\.?\s*(\d+)`)
+	episodePattern = regexp.MustCompile(`\b(?:episode|ep)\s*\.?\s*(\d+)`)
+	finalPattern   = regexp.MustCompile(`\b(?:final|complete|completed)\b`)
+	prePattern     = regexp.MustCompile(`(?:^|[^a-z])((alpha|beta|rc|preview|pre)[.-]?(\d*))(?:[^a-z]|$)`)
+	numberPattern  = regexp.MustCompile(`(\d+(?:\.\d+)*)([a-z])?`)
+)
+
+// preRanks orders pre-release markers; anything without a marker ranks 0.
+var preRanks = map[string]int{
+	"alpha":   -3,
+	"beta":    -2,
+	"preview": -1,
+	"pre":     -1,
+	"rc":      -1,
+}
+
+// Parse turns a free-form version label into a comparable Version. It never
+// fails; labels without any recognisable component compare by their text.
+func Parse(s string) Version {
+	v := Version{Raw: strings.TrimSpace(s)}
+	rest := strings.ToLower(v.Raw)
+
+	if m := datePattern.FindStringSubmatch(rest); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		v.Build = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		rest = strings.Replace(rest, m[0], " ", 1)
+	}
+
+	v.Season, rest = takeNumber(seasonPattern, rest)
+	v.Chapter, rest = takeNumber(chapterPattern, rest)
+	v.Episode, rest = takeNumber(episodePattern, rest)
+
+	if finalPattern.MatchString(rest) {
+		v.Final = true
+	}
+	// Markers may follow the number directly, as in "1.0beta" or "1.0-rc2"
+	if m := prePattern.FindStringSubmatchIndex(rest); m != nil {
+		v.Pre = preRanks[rest[m[4]:m[5]]]
+		v.PreNum, _ = strconv.Atoi(rest[m[6]:m[7]])
+		rest = rest[:m[2]] + " " + rest[m[3]:]
+	}
+
+	if m := numberPattern.FindStringSubmatch(rest); m != nil {
+		for _, part := range strings.Split(m[1], ".") {
+			n, _ := strconv.Atoi(part)
+			v.Numbers = append(v.Numbers, n)
+		}
+		v.Suffix = m[2]
+	}
+
+	return v
+}
+
+// takeNumber removes the first match of pattern from s and returns its number.
+func takeNumber(pattern *regexp.Regexp, s string) (int, string) {
+	m := pattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, s
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n, strings.Replace(s, m[0], " ", 1)
+}
+
+// String returns the original label
+func (v Version) String() string {
+	return v.Raw
+}
+
+// IsZero reports whether no comparable component was recognised
+func (v Version) IsZero() bool {
+	return v.Season == 0 && v.Chapter == 0 && v.Episode == 0 &&
+		len(v.Numbers) == 0 && v.Build.IsZero() && !v.Final
+}
+
+// bareFinal reports whether the label is only a "Final" marker
+func (v Version) bareFinal() bool {
+	return v.Final && v.Season == 0 && v.Chapter == 0 && v.Episode == 0 &&
+		len(v.Numbers) == 0 && v.Build.IsZero()
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is older than, equal to
+// or newer than o.
+func (v Version) Compare(o Version) int {
+	// A plain "Final" outranks any numbered build of the same game
+	if v.bareFinal() != o.bareFinal() {
+		if v.bareFinal() {
+			return 1
+		}
+		return -1
+	}
+
+	if v.IsZero() && o.IsZero() {
+		return strings.Compare(strings.ToLower(v.Raw), strings.ToLower(o.Raw))
+	}
+
+	if c := compareInts(v.Season, o.Season); c != 0 {
+		return c
+	}
+	if c := compareInts(v.Chapter, o.Chapter); c != 0 {
+		return c
+	}
+	if c := compareInts(v.Episode, o.Episode); c != 0 {
+		return c
+	}
+
+	for i := 0; i < len(v.Numbers) || i < len(o.Numbers); i++ {
+		if c := compareInts(part(v.Numbers, i), part(o.Numbers, i)); c != 0 {
+			return c
+		}
+	}
+
+	if c := compareInts(v.Pre, o.Pre); c != 0 {
+		return c
+	}
+	if c := compareInts(v.PreNum, o.PreNum); c != 0 {
+		return c
+	}
+	// A letter suffix marks a hotfix build: 0.5 < 0.5a < 0.5b
+	if c := strings.Compare(v.Suffix, o.Suffix); c != 0 {
+		return c
+	}
+	if c := compareTimes(v.Build, o.Build); c != 0 {
+		return c
+	}
+
+	if v.Final != o.Final {
+		if v.Final {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// Compare parses both labels and compares them, see Version.Compare
+func Compare(a, b string) int {
+	return Parse(a).Compare(Parse(b))
+}
+
+// IsNewer reports whether candidate is strictly newer than current. Empty
+// labels are never considered newer or older than anything.
+func IsNewer(candidate, current string) bool {
+	if strings.TrimSpace(candidate) == "" || strings.TrimSpace(current) == "" {
+		return false
+	}
+	return Compare(candidate, current) > 0
+}
+
+func part(numbers []int, i int) int {
+	if i < len(numbers) {
+		return numbers[i]
+	}
+	return 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
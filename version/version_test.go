@@ -0,0 +1,106 @@
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// Plain and prefixed numbers
+		{"1.0", "1.0", 0},
+		{"v1.0", "1.0", 0},
+		{"V1.2", "v1.2", 0},
+		{"1.0", "1.0.0", 0},
+		{"1.10", "1.9", 1},
+		{"0.12.3", "0.12.10", -1},
+		{"v2", "1.99", 1},
+		{"0.514.0.3 with RTP", "0.514.0.2", 1},
+
+		// Prereleases rank below the release and in order among themselves
+		{"1.0-alpha", "1.0-beta", -1},
+		{"1.0-beta", "1.0-rc", -1},
+		{"1.0-rc", "1.0", -1},
+		{"1.0 beta", "0.9", 1},
+		{"v1.3.0-beta", "v1.2.0", 1},
+		{"1.0beta", "1.0", -1},
+		{"1.0-rc1", "1.0", -1},
+		{"1.0-rc2", "1.0-rc1", 1},
+		{"1.0-rc1", "1.0-rc", 1},
+		{"1.0.0-beta.2", "1.0.0-beta.1", 1},
+		{"1.0 Preview", "1.0 alpha", 1},
+
+		// Letter suffixes mark hotfix builds
+		{"0.5a", "0.5", 1},
+		{"0.5b", "0.5a", 1},
+		{"v0.12.3b", "v0.12.3", 1},
+		{"0.6", "0.5b", 1},
+
+		// Seasons, chapters and episodes
+		{"Ch.4 Ep.2", "Ch.4 Ep.1", 1},
+		{"Chapter 5", "Ch.4 Ep.9", 1},
+		{"Season 2 v1.0", "Season 1 v3.0", 1},
+		{"Episode 3", "Ep.3", 0},
+
+		// Build dates and final releases
+		{"Build 2024-05-01", "Build 2024-04-30", 1},
+		{"Final", "0.99", 1},
+		{"1.0 Final", "1.0", 1},
+		{"Completed", "Final", 0},
+
+		// Labels without numbers compare by their text
+		{"alpha", "beta", -1},
+	}
+
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Compare(tt.b, tt.a); got != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		candidate, current string
+		want               bool
+	}{
+		{"1.1", "1.0", true},
+		{"1.0", "1.0", false},
+		{"v1.0", "1.0", false},
+		{"1.0", "1.1", false},
+		{"1.0", "1.0-rc", true},
+		{"", "1.0", false},
+		{"1.0", " ", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNewer(tt.candidate, tt.current); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.candidate, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	v := Parse("Season 2 Ch.4 Ep.3 v0.12.3b beta")
+	if v.Season != 2 || v.Chapter != 4 || v.Episode != 3 {
+		t.Errorf("Season, Chapter, Episode = %d, %d, %d, want 2, 4, 3", v.Season, v.Chapter, v.Episode)
+	}
+	if len(v.Numbers) != 3 || v.Numbers[0] != 0 || v.Numbers[1] != 12 || v.Numbers[2] != 3 {
+		t.Errorf("Numbers = %v, want [0 12 3]", v.Numbers)
+	}
+	if v.Suffix != "b" {
+		t.Errorf("Suffix = %q, want b", v.Suffix)
+	}
+	if v.Pre >= 0 {
+		t.Errorf("Pre = %d, want a prerelease rank", v.Pre)
+	}
+	if v.IsZero() {
+		t.Error("IsZero = true")
+	}
+	if !Parse("Release").IsZero() {
+		t.Error("IsZero = false for a label without numbers")
+	}
+}